@@ -2,13 +2,17 @@ package ast
 
 import (
 	"bytes"
+	"fmt"
+	"math/big"
 	"strings"
+	"unicode"
 
 	"github.com/eugene-whitaker/writing-an-interpreter-in-go/token"
 )
 
 type Node interface {
 	TokenLexeme() string
+	TokenPosition() (int, int) // Line and column of the token, or 0, 0
 	String() string
 }
 
@@ -34,6 +38,14 @@ func (p *Program) TokenLexeme() string {
 	}
 }
 
+func (p *Program) TokenPosition() (int, int) {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].TokenPosition()
+	} else {
+		return 0, 0
+	}
+}
+
 func (p *Program) String() string {
 	var out bytes.Buffer
 
@@ -55,6 +67,10 @@ func (ls *LetStatement) TokenLexeme() string {
 	return ls.Token.Lexeme
 }
 
+func (ls *LetStatement) TokenPosition() (int, int) {
+	return ls.Token.Line, ls.Token.Column
+}
+
 func (ls *LetStatement) String() string {
 	var out bytes.Buffer
 
@@ -82,6 +98,10 @@ func (rs *ReturnStatement) TokenLexeme() string {
 	return rs.Token.Lexeme
 }
 
+func (rs *ReturnStatement) TokenPosition() (int, int) {
+	return rs.Token.Line, rs.Token.Column
+}
+
 func (rs *ReturnStatement) String() string {
 	var out bytes.Buffer
 
@@ -97,6 +117,35 @@ func (rs *ReturnStatement) String() string {
 	return out.String()
 }
 
+type ThrowStatement struct {
+	Token token.Token // the 'throw' token
+	Value Expression
+}
+
+func (ts *ThrowStatement) statementNode() {}
+func (ts *ThrowStatement) TokenLexeme() string {
+	return ts.Token.Lexeme
+}
+
+func (ts *ThrowStatement) TokenPosition() (int, int) {
+	return ts.Token.Line, ts.Token.Column
+}
+
+func (ts *ThrowStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ts.TokenLexeme())
+	out.WriteString(" ")
+
+	if ts.Value != nil {
+		out.WriteString(ts.Value.String())
+	}
+
+	out.WriteString(";")
+
+	return out.String()
+}
+
 type ExpressionStatement struct {
 	Token      token.Token // The first token of the expression
 	Expression Expression
@@ -107,6 +156,10 @@ func (es *ExpressionStatement) TokenLexeme() string {
 	return es.Token.Lexeme
 }
 
+func (es *ExpressionStatement) TokenPosition() (int, int) {
+	return es.Token.Line, es.Token.Column
+}
+
 func (es *ExpressionStatement) String() string {
 	return es.Expression.String()
 }
@@ -121,6 +174,10 @@ func (bs *BlockStatement) TokenLexeme() string {
 	return bs.Token.Lexeme
 }
 
+func (bs *BlockStatement) TokenPosition() (int, int) {
+	return bs.Token.Line, bs.Token.Column
+}
+
 func (bs *BlockStatement) String() string {
 	var out bytes.Buffer
 
@@ -131,6 +188,147 @@ func (bs *BlockStatement) String() string {
 	return out.String()
 }
 
+type WhileStatement struct {
+	Token     token.Token // The 'while' token
+	Condition Expression
+	Body      *BlockStatement
+}
+
+func (ws *WhileStatement) statementNode() {}
+func (ws *WhileStatement) TokenLexeme() string {
+	return ws.Token.Lexeme
+}
+
+func (ws *WhileStatement) TokenPosition() (int, int) {
+	return ws.Token.Line, ws.Token.Column
+}
+
+func (ws *WhileStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ws.TokenLexeme())
+	out.WriteString(" ")
+	out.WriteString(ws.Condition.String())
+	out.WriteString(" ")
+	out.WriteString(ws.Body.String())
+
+	return out.String()
+}
+
+type ForStatement struct {
+	Token     token.Token // The 'for' token
+	Init      Statement   // optional
+	Condition Expression  // optional
+	Update    Statement   // optional
+	Body      *BlockStatement
+}
+
+func (fs *ForStatement) statementNode() {}
+func (fs *ForStatement) TokenLexeme() string {
+	return fs.Token.Lexeme
+}
+
+func (fs *ForStatement) TokenPosition() (int, int) {
+	return fs.Token.Line, fs.Token.Column
+}
+
+func (fs *ForStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(fs.TokenLexeme())
+	out.WriteString(" (")
+	if fs.Init != nil {
+		out.WriteString(strings.TrimSuffix(fs.Init.String(), ";"))
+	}
+	out.WriteString(";")
+	if fs.Condition != nil {
+		out.WriteString(" ")
+		out.WriteString(fs.Condition.String())
+	}
+	out.WriteString(";")
+	if fs.Update != nil {
+		out.WriteString(" ")
+		out.WriteString(strings.TrimSuffix(fs.Update.String(), ";"))
+	}
+	out.WriteString(") ")
+	out.WriteString(fs.Body.String())
+
+	return out.String()
+}
+
+type BreakStatement struct {
+	Token token.Token // The 'break' token
+}
+
+func (bs *BreakStatement) statementNode() {}
+func (bs *BreakStatement) TokenLexeme() string {
+	return bs.Token.Lexeme
+}
+
+func (bs *BreakStatement) TokenPosition() (int, int) {
+	return bs.Token.Line, bs.Token.Column
+}
+
+func (bs *BreakStatement) String() string {
+	return bs.TokenLexeme() + ";"
+}
+
+type ContinueStatement struct {
+	Token token.Token // The 'continue' token
+}
+
+func (cs *ContinueStatement) statementNode() {}
+func (cs *ContinueStatement) TokenLexeme() string {
+	return cs.Token.Lexeme
+}
+
+func (cs *ContinueStatement) TokenPosition() (int, int) {
+	return cs.Token.Line, cs.Token.Column
+}
+
+func (cs *ContinueStatement) String() string {
+	return cs.TokenLexeme() + ";"
+}
+
+type TryStatement struct {
+	Token     token.Token // The 'try' token
+	Body      *BlockStatement
+	Parameter *Identifier     // optional, with Catch
+	Catch     *BlockStatement // optional if Finally is present
+	Finally   *BlockStatement // optional if Catch is present
+}
+
+func (ts *TryStatement) statementNode() {}
+func (ts *TryStatement) TokenLexeme() string {
+	return ts.Token.Lexeme
+}
+
+func (ts *TryStatement) TokenPosition() (int, int) {
+	return ts.Token.Line, ts.Token.Column
+}
+
+func (ts *TryStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ts.TokenLexeme())
+	out.WriteString(" ")
+	out.WriteString(ts.Body.String())
+
+	if ts.Catch != nil {
+		out.WriteString(" catch (")
+		out.WriteString(ts.Parameter.String())
+		out.WriteString(") ")
+		out.WriteString(ts.Catch.String())
+	}
+
+	if ts.Finally != nil {
+		out.WriteString(" finally ")
+		out.WriteString(ts.Finally.String())
+	}
+
+	return out.String()
+}
+
 type Identifier struct {
 	Token token.Token // The token.IDENT token
 	Value string
@@ -141,6 +339,10 @@ func (i *Identifier) TokenLexeme() string {
 	return i.Token.Lexeme
 }
 
+func (i *Identifier) TokenPosition() (int, int) {
+	return i.Token.Line, i.Token.Column
+}
+
 func (i *Identifier) String() string {
 	return i.Value
 }
@@ -155,10 +357,50 @@ func (il *IntegerLiteral) TokenLexeme() string {
 	return il.Token.Lexeme
 }
 
+func (il *IntegerLiteral) TokenPosition() (int, int) {
+	return il.Token.Line, il.Token.Column
+}
+
 func (il *IntegerLiteral) String() string {
 	return il.Token.Lexeme
 }
 
+type BigIntegerLiteral struct {
+	Token token.Token
+	Value *big.Int
+}
+
+func (bil *BigIntegerLiteral) expressionNode() {}
+func (bil *BigIntegerLiteral) TokenLexeme() string {
+	return bil.Token.Lexeme
+}
+
+func (bil *BigIntegerLiteral) TokenPosition() (int, int) {
+	return bil.Token.Line, bil.Token.Column
+}
+
+func (bil *BigIntegerLiteral) String() string {
+	return bil.Token.Lexeme
+}
+
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode() {}
+func (fl *FloatLiteral) TokenLexeme() string {
+	return fl.Token.Lexeme
+}
+
+func (fl *FloatLiteral) TokenPosition() (int, int) {
+	return fl.Token.Line, fl.Token.Column
+}
+
+func (fl *FloatLiteral) String() string {
+	return fl.Token.Lexeme
+}
+
 type BooleanLiteral struct {
 	Token token.Token
 	Value bool
@@ -169,13 +411,20 @@ func (bl *BooleanLiteral) TokenLexeme() string {
 	return bl.Token.Lexeme
 }
 
+func (bl *BooleanLiteral) TokenPosition() (int, int) {
+	return bl.Token.Line, bl.Token.Column
+}
+
 func (bl *BooleanLiteral) String() string {
 	return bl.Token.Lexeme
 }
 
 type FunctionLiteral struct {
 	Token      token.Token // The 'fn' token
+	Name       string      // Set when the literal is bound by a let statement
 	Parameters []*Identifier
+	Defaults   []Expression // Parallel to Parameters, nil where there is no default
+	Rest       *Identifier  // The '...rest' parameter, if any
 	Body       *BlockStatement
 }
 
@@ -184,12 +433,23 @@ func (fl *FunctionLiteral) TokenLexeme() string {
 	return fl.Token.Lexeme
 }
 
+func (fl *FunctionLiteral) TokenPosition() (int, int) {
+	return fl.Token.Line, fl.Token.Column
+}
+
 func (fl *FunctionLiteral) String() string {
 	var out bytes.Buffer
 
 	ps := []string{}
-	for _, p := range fl.Parameters {
-		ps = append(ps, p.String())
+	for i, p := range fl.Parameters {
+		if i < len(fl.Defaults) && fl.Defaults[i] != nil {
+			ps = append(ps, p.String()+" = "+fl.Defaults[i].String())
+		} else {
+			ps = append(ps, p.String())
+		}
+	}
+	if fl.Rest != nil {
+		ps = append(ps, "..."+fl.Rest.String())
 	}
 
 	out.WriteString(fl.TokenLexeme())
@@ -211,8 +471,44 @@ func (sl *StringLiteral) TokenLexeme() string {
 	return sl.Token.Lexeme
 }
 
+func (sl *StringLiteral) TokenPosition() (int, int) {
+	return sl.Token.Line, sl.Token.Column
+}
+
 func (sl *StringLiteral) String() string {
-	return sl.Token.Lexeme
+	return "\"" + escape(sl.Value) + "\""
+}
+
+type InterpolatedString struct {
+	Token token.Token // The token.STRING_HEAD token
+	Parts []Expression
+}
+
+func (is *InterpolatedString) expressionNode() {}
+func (is *InterpolatedString) TokenLexeme() string {
+	return is.Token.Lexeme
+}
+
+func (is *InterpolatedString) TokenPosition() (int, int) {
+	return is.Token.Line, is.Token.Column
+}
+
+func (is *InterpolatedString) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("\"")
+	for _, part := range is.Parts {
+		if str, ok := part.(*StringLiteral); ok {
+			out.WriteString(escape(str.Value))
+		} else {
+			out.WriteString("${")
+			out.WriteString(part.String())
+			out.WriteString("}")
+		}
+	}
+	out.WriteString("\"")
+
+	return out.String()
 }
 
 type ArrayLiteral struct {
@@ -225,6 +521,10 @@ func (al *ArrayLiteral) TokenLexeme() string {
 	return al.Token.Lexeme
 }
 
+func (al *ArrayLiteral) TokenPosition() (int, int) {
+	return al.Token.Line, al.Token.Column
+}
+
 func (al *ArrayLiteral) String() string {
 	var out bytes.Buffer
 
@@ -241,8 +541,9 @@ func (al *ArrayLiteral) String() string {
 }
 
 type HashLiteral struct {
-	Token token.Token // The '{' token
-	Pairs map[Expression]Expression
+	Token  token.Token // The '{' token
+	Keys   []Expression
+	Values []Expression // Values[i] is paired with Keys[i], in source order
 }
 
 func (hl *HashLiteral) expressionNode() {}
@@ -250,12 +551,16 @@ func (hl *HashLiteral) TokenLexeme() string {
 	return hl.Token.Lexeme
 }
 
+func (hl *HashLiteral) TokenPosition() (int, int) {
+	return hl.Token.Line, hl.Token.Column
+}
+
 func (hl *HashLiteral) String() string {
 	var out bytes.Buffer
 
 	ps := []string{}
-	for k, v := range hl.Pairs {
-		ps = append(ps, k.String()+":"+v.String())
+	for i, key := range hl.Keys {
+		ps = append(ps, key.String()+":"+hl.Values[i].String())
 	}
 
 	out.WriteString("{")
@@ -276,6 +581,10 @@ func (pe *PrefixExpression) TokenLexeme() string {
 	return pe.Token.Lexeme
 }
 
+func (pe *PrefixExpression) TokenPosition() (int, int) {
+	return pe.Token.Line, pe.Token.Column
+}
+
 func (pe *PrefixExpression) String() string {
 	var out bytes.Buffer
 
@@ -299,6 +608,10 @@ func (ie *InfixExpression) TokenLexeme() string {
 	return ie.Token.Lexeme
 }
 
+func (ie *InfixExpression) TokenPosition() (int, int) {
+	return ie.Token.Line, ie.Token.Column
+}
+
 func (ie *InfixExpression) String() string {
 	var out bytes.Buffer
 
@@ -313,6 +626,36 @@ func (ie *InfixExpression) String() string {
 	return out.String()
 }
 
+type AssignExpression struct {
+	Token    token.Token // The operator token, e.g. '=' or '+='
+	Target   Expression  // Identifier or IndexExpression
+	Operator string
+	Value    Expression
+}
+
+func (ae *AssignExpression) expressionNode() {}
+func (ae *AssignExpression) TokenLexeme() string {
+	return ae.Token.Lexeme
+}
+
+func (ae *AssignExpression) TokenPosition() (int, int) {
+	return ae.Token.Line, ae.Token.Column
+}
+
+func (ae *AssignExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ae.Target.String())
+	out.WriteString(" ")
+	out.WriteString(ae.Operator)
+	out.WriteString(" ")
+	out.WriteString(ae.Value.String())
+	out.WriteString(")")
+
+	return out.String()
+}
+
 type IfExpression struct {
 	Token       token.Token // The 'if' Token
 	Condition   Expression
@@ -325,6 +668,10 @@ func (ie *IfExpression) TokenLexeme() string {
 	return ie.Token.Lexeme
 }
 
+func (ie *IfExpression) TokenPosition() (int, int) {
+	return ie.Token.Line, ie.Token.Column
+}
+
 func (ie *IfExpression) String() string {
 	var out bytes.Buffer
 
@@ -353,6 +700,10 @@ func (ce *CallExpression) TokenLexeme() string {
 	return ce.Token.Lexeme
 }
 
+func (ce *CallExpression) TokenPosition() (int, int) {
+	return ce.Token.Line, ce.Token.Column
+}
+
 func (ce *CallExpression) String() string {
 	var out bytes.Buffer
 
@@ -380,6 +731,10 @@ func (ie *IndexExpression) TokenLexeme() string {
 	return ie.Token.Lexeme
 }
 
+func (ie *IndexExpression) TokenPosition() (int, int) {
+	return ie.Token.Line, ie.Token.Column
+}
+
 func (ie *IndexExpression) String() string {
 	var out bytes.Buffer
 
@@ -392,6 +747,40 @@ func (ie *IndexExpression) String() string {
 	return out.String()
 }
 
+type SliceExpression struct {
+	Token  token.Token // The '[' token
+	Struct Expression
+	Start  Expression // nil when omitted
+	End    Expression // nil when omitted
+}
+
+func (se *SliceExpression) expressionNode() {}
+func (se *SliceExpression) TokenLexeme() string {
+	return se.Token.Lexeme
+}
+
+func (se *SliceExpression) TokenPosition() (int, int) {
+	return se.Token.Line, se.Token.Column
+}
+
+func (se *SliceExpression) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(se.Struct.String())
+	out.WriteString("[")
+	if se.Start != nil {
+		out.WriteString(se.Start.String())
+	}
+	out.WriteString(":")
+	if se.End != nil {
+		out.WriteString(se.End.String())
+	}
+	out.WriteString("])")
+
+	return out.String()
+}
+
 type MacroExpression struct {
 	Token      token.Token // The 'macro' token
 	Parameters []*Identifier
@@ -403,6 +792,10 @@ func (me *MacroExpression) TokenLexeme() string {
 	return me.Token.Lexeme
 }
 
+func (me *MacroExpression) TokenPosition() (int, int) {
+	return me.Token.Line, me.Token.Column
+}
+
 func (me *MacroExpression) String() string {
 	var out bytes.Buffer
 
@@ -419,3 +812,35 @@ func (me *MacroExpression) String() string {
 
 	return out.String()
 }
+
+// escape renders s as the body of a string literal that lexes back to s.
+func escape(s string) string {
+	var out bytes.Buffer
+
+	for i, r := range s {
+		switch r {
+		case '\n':
+			out.WriteString("\\n")
+		case '\t':
+			out.WriteString("\\t")
+		case '\\':
+			out.WriteString("\\\\")
+		case '"':
+			out.WriteString("\\\"")
+		case '$':
+			if strings.HasPrefix(s[i+1:], "{") {
+				out.WriteString("\\$")
+			} else {
+				out.WriteRune(r)
+			}
+		default:
+			if unicode.IsPrint(r) || r == ' ' {
+				out.WriteRune(r)
+			} else {
+				out.WriteString(fmt.Sprintf("\\u{%x}", r))
+			}
+		}
+	}
+
+	return out.String()
+}
@@ -13,35 +13,71 @@ func Modify(node Node, modifier ModifierFunc) Node {
 		node.Value, _ = Modify(node.Value, modifier).(Expression)
 	case *ReturnStatement:
 		node.ReturnValue, _ = Modify(node.ReturnValue, modifier).(Expression)
+	case *ThrowStatement:
+		node.Value, _ = Modify(node.Value, modifier).(Expression)
 	case *ExpressionStatement:
 		node.Expression, _ = Modify(node.Expression, modifier).(Expression)
 	case *BlockStatement:
 		for i, stmt := range node.Statements {
 			node.Statements[i], _ = Modify(stmt, modifier).(Statement)
 		}
+	case *WhileStatement:
+		node.Condition, _ = Modify(node.Condition, modifier).(Expression)
+		node.Body, _ = Modify(node.Body, modifier).(*BlockStatement)
+	case *ForStatement:
+		if node.Init != nil {
+			node.Init, _ = Modify(node.Init, modifier).(Statement)
+		}
+		if node.Condition != nil {
+			node.Condition, _ = Modify(node.Condition, modifier).(Expression)
+		}
+		if node.Update != nil {
+			node.Update, _ = Modify(node.Update, modifier).(Statement)
+		}
+		node.Body, _ = Modify(node.Body, modifier).(*BlockStatement)
+	case *TryStatement:
+		node.Body, _ = Modify(node.Body, modifier).(*BlockStatement)
+		if node.Catch != nil {
+			node.Parameter, _ = Modify(node.Parameter, modifier).(*Identifier)
+			node.Catch, _ = Modify(node.Catch, modifier).(*BlockStatement)
+		}
+		if node.Finally != nil {
+			node.Finally, _ = Modify(node.Finally, modifier).(*BlockStatement)
+		}
 	case *FunctionLiteral:
 		for i, ident := range node.Parameters {
 			node.Parameters[i], _ = Modify(ident, modifier).(*Identifier)
 		}
+		for i, def := range node.Defaults {
+			if def != nil {
+				node.Defaults[i], _ = Modify(def, modifier).(Expression)
+			}
+		}
+		if node.Rest != nil {
+			node.Rest, _ = Modify(node.Rest, modifier).(*Identifier)
+		}
 		node.Body, _ = Modify(node.Body, modifier).(*BlockStatement)
+	case *InterpolatedString:
+		for i, part := range node.Parts {
+			node.Parts[i], _ = Modify(part, modifier).(Expression)
+		}
 	case *ArrayLiteral:
 		for i, elem := range node.Elements {
 			node.Elements[i], _ = Modify(elem, modifier).(Expression)
 		}
 	case *HashLiteral:
-		pairs := make(map[Expression]Expression)
-		for key, value := range node.Pairs {
-			key, _ = Modify(key, modifier).(Expression)
-			value, _ = Modify(value, modifier).(Expression)
-
-			pairs[key] = value
+		for i := range node.Keys {
+			node.Keys[i], _ = Modify(node.Keys[i], modifier).(Expression)
+			node.Values[i], _ = Modify(node.Values[i], modifier).(Expression)
 		}
-		node.Pairs = pairs
 	case *PrefixExpression:
 		node.Right, _ = Modify(node.Right, modifier).(Expression)
 	case *InfixExpression:
 		node.Left, _ = Modify(node.Left, modifier).(Expression)
 		node.Right, _ = Modify(node.Right, modifier).(Expression)
+	case *AssignExpression:
+		node.Target, _ = Modify(node.Target, modifier).(Expression)
+		node.Value, _ = Modify(node.Value, modifier).(Expression)
 	case *IfExpression:
 		node.Condition, _ = Modify(node.Condition, modifier).(Expression)
 		node.Consequence, _ = Modify(node.Consequence, modifier).(*BlockStatement)
@@ -56,6 +92,14 @@ func Modify(node Node, modifier ModifierFunc) Node {
 	case *IndexExpression:
 		node.Struct, _ = Modify(node.Struct, modifier).(Expression)
 		node.Index, _ = Modify(node.Index, modifier).(Expression)
+	case *SliceExpression:
+		node.Struct, _ = Modify(node.Struct, modifier).(Expression)
+		if node.Start != nil {
+			node.Start, _ = Modify(node.Start, modifier).(Expression)
+		}
+		if node.End != nil {
+			node.End, _ = Modify(node.End, modifier).(Expression)
+		}
 	}
 
 	return modifier(node)
@@ -161,6 +161,172 @@ func TestModify(t *testing.T) {
 				},
 			},
 		},
+		{
+			&FunctionLiteral{
+				Parameters: []*Identifier{
+					{Value: "x"},
+				},
+				Defaults: []Expression{
+					one(),
+				},
+				Body: &BlockStatement{
+					Statements: []Statement{},
+				},
+			},
+			&FunctionLiteral{
+				Parameters: []*Identifier{
+					{Value: "x"},
+				},
+				Defaults: []Expression{
+					two(),
+				},
+				Body: &BlockStatement{
+					Statements: []Statement{},
+				},
+			},
+		},
+		{
+			&TryStatement{
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&ThrowStatement{
+							Value: one(),
+						},
+					},
+				},
+				Parameter: &Identifier{Value: "e"},
+				Catch: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{
+							Expression: one(),
+						},
+					},
+				},
+				Finally: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{
+							Expression: one(),
+						},
+					},
+				},
+			},
+			&TryStatement{
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&ThrowStatement{
+							Value: two(),
+						},
+					},
+				},
+				Parameter: &Identifier{Value: "e"},
+				Catch: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{
+							Expression: two(),
+						},
+					},
+				},
+				Finally: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{
+							Expression: two(),
+						},
+					},
+				},
+			},
+		},
+		{
+			&WhileStatement{
+				Condition: one(),
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{
+							Expression: one(),
+						},
+					},
+				},
+			},
+			&WhileStatement{
+				Condition: two(),
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&ExpressionStatement{
+							Expression: two(),
+						},
+					},
+				},
+			},
+		},
+		{
+			&ForStatement{
+				Init: &LetStatement{
+					Value: one(),
+				},
+				Condition: one(),
+				Update: &ExpressionStatement{
+					Expression: one(),
+				},
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&BreakStatement{},
+					},
+				},
+			},
+			&ForStatement{
+				Init: &LetStatement{
+					Value: two(),
+				},
+				Condition: two(),
+				Update: &ExpressionStatement{
+					Expression: two(),
+				},
+				Body: &BlockStatement{
+					Statements: []Statement{
+						&BreakStatement{},
+					},
+				},
+			},
+		},
+		{
+			&HashLiteral{
+				Keys: []Expression{
+					one(),
+					one(),
+				},
+				Values: []Expression{
+					one(),
+					one(),
+				},
+			},
+			&HashLiteral{
+				Keys: []Expression{
+					two(),
+					two(),
+				},
+				Values: []Expression{
+					two(),
+					two(),
+				},
+			},
+		},
+		{
+			&SliceExpression{
+				Struct: &ArrayLiteral{
+					Elements: []Expression{
+						one(),
+					},
+				},
+				End: one(),
+			},
+			&SliceExpression{
+				Struct: &ArrayLiteral{
+					Elements: []Expression{
+						two(),
+					},
+				},
+				End: two(),
+			},
+		},
 		{
 			&ArrayLiteral{
 				Elements: []Expression{
@@ -185,24 +351,4 @@ func TestModify(t *testing.T) {
 			continue
 		}
 	}
-
-	input := &HashLiteral{
-		Pairs: map[Expression]Expression{
-			one(): one(),
-			one(): one(),
-		},
-	}
-	Modify(input, modifier)
-
-	for key, val := range input.Pairs {
-		key, _ := key.(*IntegerLiteral)
-		if 2 != key.Value {
-			t.Errorf("key.Value ==> expected: %d actual: %d", 2, key.Value)
-		}
-
-		val, _ := val.(*IntegerLiteral)
-		if 2 != val.Value {
-			t.Errorf("val.Value ==> expected: %d actual: %d", 2, val.Value)
-		}
-	}
 }
@@ -2,7 +2,11 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/eugene-whitaker/writing-an-interpreter-in-go/object"
 )
@@ -24,7 +28,7 @@ var builtins = map[string]*object.Builtin{
 
 			switch arg := args[0].(type) {
 			case *object.String:
-				return toIntegerObject(int64(len(arg.Value)))
+				return toIntegerObject(int64(arg.Length()))
 			case *object.Array:
 				return toIntegerObject(int64(len(arg.Elements)))
 			default:
@@ -35,6 +39,62 @@ var builtins = map[string]*object.Builtin{
 			}
 		},
 	},
+	"bytes_len": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				types := []string{}
+				for _, arg := range args {
+					types = append(types, string(arg.Type()))
+				}
+
+				return toErrorObject(
+					"invalid argument count in call to `bytes_len`: found (%s) want (STRING)",
+					strings.Join(types, ", "),
+				)
+			}
+
+			switch arg := args[0].(type) {
+			case *object.String:
+				return toIntegerObject(int64(len(arg.Value)))
+			default:
+				return toErrorObject(
+					"invalid argument types in call to `bytes_len`: found (%s) want (STRING)",
+					arg.Type(),
+				)
+			}
+		},
+	},
+	"chars": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				types := []string{}
+				for _, arg := range args {
+					types = append(types, string(arg.Type()))
+				}
+
+				return toErrorObject(
+					"invalid argument count in call to `chars`: found (%s) want (STRING)",
+					strings.Join(types, ", "),
+				)
+			}
+
+			switch arg := args[0].(type) {
+			case *object.String:
+				elements := []object.Object{}
+				for str := arg.Value; len(str) > 0; {
+					_, size := utf8.DecodeRuneInString(str)
+					elements = append(elements, toStringObject(str[:size]))
+					str = str[size:]
+				}
+				return toArrayObject(elements)
+			default:
+				return toErrorObject(
+					"invalid argument types in call to `chars`: found (%s) want (STRING)",
+					arg.Type(),
+				)
+			}
+		},
+	},
 	"first": {
 		Fn: func(args ...object.Object) object.Object {
 			if len(args) != 1 {
@@ -156,6 +216,76 @@ var builtins = map[string]*object.Builtin{
 			}
 		},
 	},
+	"int": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				types := []string{}
+				for _, arg := range args {
+					types = append(types, string(arg.Type()))
+				}
+
+				return toErrorObject(
+					"invalid argument count in call to `int`: found (%s) want (INTEGER) or (FLOAT) or (STRING)",
+					strings.Join(types, ", "),
+				)
+			}
+
+			switch arg := args[0].(type) {
+			case *object.Integer, *object.BigInteger:
+				return arg
+			case *object.Float:
+				if math.IsNaN(arg.Value) || math.IsInf(arg.Value, 0) {
+					return toErrorObject("invalid argument value in call to `int`: cannot convert %s to INTEGER", arg.Inspect())
+				}
+				value, _ := big.NewFloat(arg.Value).Int(nil)
+				return toBigIntegerObject(value)
+			case *object.String:
+				value, ok := new(big.Int).SetString(arg.Value, 10)
+				if !ok {
+					return toErrorObject("invalid argument value in call to `int`: cannot convert %q to INTEGER", arg.Value)
+				}
+				return toBigIntegerObject(value)
+			default:
+				return toErrorObject(
+					"invalid argument types in call to `int`: found (%s) want (INTEGER) or (FLOAT) or (STRING)",
+					arg.Type(),
+				)
+			}
+		},
+	},
+	"float": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				types := []string{}
+				for _, arg := range args {
+					types = append(types, string(arg.Type()))
+				}
+
+				return toErrorObject(
+					"invalid argument count in call to `float`: found (%s) want (INTEGER) or (FLOAT) or (STRING)",
+					strings.Join(types, ", "),
+				)
+			}
+
+			switch arg := args[0].(type) {
+			case *object.Integer, *object.BigInteger:
+				return toFloatObject(toFloat(arg))
+			case *object.Float:
+				return arg
+			case *object.String:
+				value, err := strconv.ParseFloat(arg.Value, 64)
+				if err != nil {
+					return toErrorObject("invalid argument value in call to `float`: cannot convert %q to FLOAT", arg.Value)
+				}
+				return toFloatObject(value)
+			default:
+				return toErrorObject(
+					"invalid argument types in call to `float`: found (%s) want (INTEGER) or (FLOAT) or (STRING)",
+					arg.Type(),
+				)
+			}
+		},
+	},
 	"puts": {
 		Fn: func(args ...object.Object) object.Object {
 			for _, arg := range args {
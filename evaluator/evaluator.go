@@ -2,6 +2,11 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/eugene-whitaker/writing-an-interpreter-in-go/ast"
 	"github.com/eugene-whitaker/writing-an-interpreter-in-go/object"
@@ -19,18 +24,33 @@ var (
 		Value: false,
 	}
 	NULL         = &object.Null{}
+	BREAK        = &object.Break{}
+	CONTINUE     = &object.Continue{}
 	EMPTY_STRING = &object.String{
 		Value: "",
 	}
-	EMPTY_ARRAY = &object.Array{
-		Elements: []object.Object{},
-	}
-	EMPTY_HASH = &object.Hash{
-		Pairs: make(map[object.HashKey]object.HashPair),
-	}
 )
 
+// MAX_REPEAT_LENGTH bounds the bytes of a string or elements of an array built
+// by *, so an oversized repeat count is an error rather than a crash.
+const MAX_REPEAT_LENGTH = 1 << 26
+
+// MAX_POWER_BITS bounds the estimated bits of an integer built by **, so an
+// oversized exponent is an error rather than exhausting memory.
+const MAX_POWER_BITS = 1 << 26
+
 func Eval(node ast.Node, env *object.Environment) object.Object {
+	result := evalNode(node, env)
+
+	if isError(result) {
+		line, column := node.TokenPosition()
+		locate(result, line, column)
+	}
+
+	return result
+}
+
+func evalNode(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 	case *ast.Program:
 		return evalProgram(node, env)
@@ -42,16 +62,34 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return evalExpressionStatement(node, env)
 	case *ast.BlockStatement:
 		return evalBlockStatement(node, env)
+	case *ast.WhileStatement:
+		return evalWhileStatement(node, env)
+	case *ast.ForStatement:
+		return evalForStatement(node, env)
+	case *ast.BreakStatement:
+		return BREAK
+	case *ast.ContinueStatement:
+		return CONTINUE
+	case *ast.TryStatement:
+		return evalTryStatement(node, env)
+	case *ast.ThrowStatement:
+		return evalThrowStatement(node, env)
 	case *ast.Identifier:
 		return evalIdentifier(node, env)
 	case *ast.IntegerLiteral:
 		return evalIntegerLiteral(node)
+	case *ast.BigIntegerLiteral:
+		return evalBigIntegerLiteral(node)
+	case *ast.FloatLiteral:
+		return evalFloatLiteral(node)
 	case *ast.BooleanLiteral:
 		return evalBooleanLiteral(node)
 	case *ast.FunctionLiteral:
 		return evalFunctionLiteral(node, env)
 	case *ast.StringLiteral:
 		return evalStringLiteral(node)
+	case *ast.InterpolatedString:
+		return evalInterpolatedString(node, env)
 	case *ast.ArrayLiteral:
 		return evalArrayLiteral(node, env)
 	case *ast.HashLiteral:
@@ -60,12 +98,16 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return evalPrefixExpression(node, env)
 	case *ast.InfixExpression:
 		return evalInfixExpression(node, env)
+	case *ast.AssignExpression:
+		return evalAssignExpression(node, env)
 	case *ast.IfExpression:
 		return evalIfExpression(node, env)
 	case *ast.CallExpression:
 		return evalCallExpression(node, env)
 	case *ast.IndexExpression:
 		return evalIndexExpression(node, env)
+	case *ast.SliceExpression:
+		return evalSliceExpression(node, env)
 	}
 
 	return nil
@@ -84,6 +126,10 @@ func evalProgram(node *ast.Program, env *object.Environment) object.Object {
 		if returnValue, ok := result.(*object.ReturnValue); ok {
 			return returnValue.Value
 		}
+
+		if tailCall, ok := result.(*object.TailCall); ok {
+			return evalFunctionCallExpression(tailCall.Function, tailCall.Arguments, tailCall.Token)
+		}
 	}
 
 	return result
@@ -102,12 +148,16 @@ func evalLetStatement(node *ast.LetStatement, env *object.Environment) object.Ob
 }
 
 func evalReturnStatement(node *ast.ReturnStatement, env *object.Environment) object.Object {
-	value := Eval(node.ReturnValue, env)
+	value := evalTailExpression(node.ReturnValue, env)
 
 	if isError(value) {
 		return value
 	}
 
+	if value.Type() == object.TAIL_CALL_OBJECT {
+		return value
+	}
+
 	return &object.ReturnValue{
 		Value: value,
 	}
@@ -127,14 +177,229 @@ func evalBlockStatement(node *ast.BlockStatement, env *object.Environment) objec
 			return result
 		}
 
-		if result != nil && result.Type() == object.RETURN_VALUE_OBJECT {
+		if result != nil {
+			switch result.Type() {
+			case object.RETURN_VALUE_OBJECT, object.TAIL_CALL_OBJECT, object.BREAK_OBJECT, object.CONTINUE_OBJECT:
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+// evalTailBlockStatement evaluates a block whose value is the value of the
+// enclosing function, so its final expression is in tail position.
+func evalTailBlockStatement(node *ast.BlockStatement, env *object.Environment) object.Object {
+	var result object.Object
+
+	for i, stmt := range node.Statements {
+		if exprStmt, ok := stmt.(*ast.ExpressionStatement); ok && i == len(node.Statements)-1 {
+			return evalTailExpression(exprStmt.Expression, env)
+		}
+
+		result = Eval(stmt, env)
+
+		if isError(result) {
+			return result
+		}
+
+		if result != nil {
+			switch result.Type() {
+			case object.RETURN_VALUE_OBJECT, object.TAIL_CALL_OBJECT, object.BREAK_OBJECT, object.CONTINUE_OBJECT:
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+// evalTailExpression evaluates an expression in tail position. A call to a
+// function is not made but returned as a tail call, and the branches of an if
+// expression are themselves in tail position.
+func evalTailExpression(node ast.Expression, env *object.Environment) object.Object {
+	switch node := node.(type) {
+	case *ast.CallExpression:
+		result := evalTailCallExpression(node, env)
+
+		if isError(result) {
+			locate(result, node.Token.Line, node.Token.Column)
+		}
+
+		return result
+	case *ast.IfExpression:
+		condition := Eval(node.Condition, env)
+
+		if isError(condition) {
+			return condition
+		}
+
+		if condition.Truthy() {
+			return evalTailBlockStatement(node.Consequence, env)
+		} else if node.Alternative != nil {
+			return evalTailBlockStatement(node.Alternative, env)
+		}
+
+		return NULL
+	}
+
+	return Eval(node, env)
+}
+
+func evalWhileStatement(node *ast.WhileStatement, env *object.Environment) object.Object {
+	for {
+		condition := Eval(node.Condition, env)
+
+		if isError(condition) {
+			return condition
+		}
+
+		if !condition.Truthy() {
+			return NULL
+		}
+
+		result := Eval(node.Body, env)
+
+		if isError(result) {
+			return result
+		}
+
+		if result != nil {
+			switch result.Type() {
+			case object.RETURN_VALUE_OBJECT, object.TAIL_CALL_OBJECT:
+				return result
+			case object.BREAK_OBJECT:
+				return NULL
+			}
+		}
+	}
+}
+
+func evalForStatement(node *ast.ForStatement, env *object.Environment) object.Object {
+	enclosed := object.NewEnclosedEnvironment(env)
+
+	if node.Init != nil {
+		init := Eval(node.Init, enclosed)
+
+		if isError(init) {
+			return init
+		}
+	}
+
+	for {
+		if node.Condition != nil {
+			condition := Eval(node.Condition, enclosed)
+
+			if isError(condition) {
+				return condition
+			}
+
+			if !condition.Truthy() {
+				return NULL
+			}
+		}
+
+		result := Eval(node.Body, enclosed)
+
+		if isError(result) {
 			return result
 		}
+
+		if result != nil {
+			switch result.Type() {
+			case object.RETURN_VALUE_OBJECT, object.TAIL_CALL_OBJECT:
+				return result
+			case object.BREAK_OBJECT:
+				return NULL
+			}
+		}
+
+		if node.Update != nil {
+			update := Eval(node.Update, enclosed)
+
+			if isError(update) {
+				return update
+			}
+		}
+	}
+}
+
+// A finally block that errors or leaves a function or loop overrides the result.
+func evalTryStatement(node *ast.TryStatement, env *object.Environment) object.Object {
+	result := resolveTailCall(Eval(node.Body, env))
+
+	if isError(result) && node.Catch != nil {
+		enclosed := object.NewEnclosedEnvironment(env)
+		enclosed.Set(node.Parameter.Value, toCaughtObject(result.(*object.Error)))
+
+		result = resolveTailCall(Eval(node.Catch, enclosed))
+	}
+
+	if node.Finally != nil {
+		final := Eval(node.Finally, env)
+
+		if isError(final) {
+			return final
+		}
+
+		if final != nil {
+			switch final.Type() {
+			case object.RETURN_VALUE_OBJECT, object.TAIL_CALL_OBJECT, object.BREAK_OBJECT, object.CONTINUE_OBJECT:
+				return final
+			}
+		}
 	}
 
 	return result
 }
 
+// resolveTailCall runs a tail call inside the try so its errors can be caught.
+func resolveTailCall(obj object.Object) object.Object {
+	tailCall, ok := obj.(*object.TailCall)
+	if !ok {
+		return obj
+	}
+
+	result := evalFunctionCallExpression(tailCall.Function, tailCall.Arguments, tailCall.Token)
+
+	if isError(result) {
+		return result
+	}
+
+	return &object.ReturnValue{
+		Value: result,
+	}
+}
+
+func evalThrowStatement(node *ast.ThrowStatement, env *object.Environment) object.Object {
+	value := Eval(node.Value, env)
+
+	if isError(value) {
+		return value
+	}
+
+	return &object.Error{
+		Message: thrownMessage(value),
+		Value:   value,
+	}
+}
+
+func thrownMessage(value object.Object) string {
+	switch value := value.(type) {
+	case *object.String:
+		return value.Value
+	case *object.Hash:
+		if message, ok := value.Get(&object.String{Value: "message"}); ok {
+			if str, ok := message.(*object.String); ok {
+				return str.Value
+			}
+		}
+	}
+
+	return value.Inspect()
+}
+
 func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
 	if obj, ok := env.Get(node.Value); ok {
 		return obj
@@ -151,13 +416,24 @@ func evalIntegerLiteral(node *ast.IntegerLiteral) object.Object {
 	return toIntegerObject(node.Value)
 }
 
+func evalBigIntegerLiteral(node *ast.BigIntegerLiteral) object.Object {
+	return toBigIntegerObject(new(big.Int).Set(node.Value))
+}
+
+func evalFloatLiteral(node *ast.FloatLiteral) object.Object {
+	return toFloatObject(node.Value)
+}
+
 func evalBooleanLiteral(node *ast.BooleanLiteral) object.Object {
 	return toBooleanObject(node.Value)
 }
 
 func evalFunctionLiteral(node *ast.FunctionLiteral, env *object.Environment) object.Object {
 	return &object.Function{
+		Name:       node.Name,
 		Parameters: node.Parameters,
+		Defaults:   node.Defaults,
+		Rest:       node.Rest,
 		Body:       node.Body,
 		Env:        env,
 	}
@@ -167,6 +443,20 @@ func evalStringLiteral(node *ast.StringLiteral) object.Object {
 	return toStringObject(node.Value)
 }
 
+func evalInterpolatedString(node *ast.InterpolatedString, env *object.Environment) object.Object {
+	var out strings.Builder
+
+	for _, part := range node.Parts {
+		result := Eval(part, env)
+		if isError(result) {
+			return result
+		}
+		out.WriteString(result.Inspect())
+	}
+
+	return toStringObject(out.String())
+}
+
 func evalArrayLiteral(node *ast.ArrayLiteral, env *object.Environment) object.Object {
 	elems := []object.Object{}
 
@@ -184,30 +474,30 @@ func evalArrayLiteral(node *ast.ArrayLiteral, env *object.Environment) object.Ob
 }
 
 func evalHashLiteral(node *ast.HashLiteral, env *object.Environment) object.Object {
-	pairs := make(map[object.HashKey]object.HashPair)
+	hash := &object.Hash{}
 
-	for key, value := range node.Pairs {
+	for i, key := range node.Keys {
 		key := Eval(key, env)
 
 		if isError(key) {
 			return key
 		}
 
-		hashkey, ok := key.(object.Hashable)
+		hashable, ok := key.(object.Hashable)
 		if !ok {
 			return toErrorObject("invalid type: %s is not hashable", key.Type())
 		}
 
-		value := Eval(value, env)
+		value := Eval(node.Values[i], env)
 
 		if isError(value) {
 			return value
 		}
 
-		pairs[hashkey.HashKey()] = object.HashPair{Key: key, Value: value}
+		hash.Set(hashable, value)
 	}
 
-	return toHashObject(pairs)
+	return hash
 }
 
 func evalPrefixExpression(node *ast.PrefixExpression, env *object.Environment) object.Object {
@@ -217,19 +507,18 @@ func evalPrefixExpression(node *ast.PrefixExpression, env *object.Environment) o
 		return right
 	}
 
+	if node.Operator == "!" {
+		return toBooleanObject(!right.Truthy())
+	}
+
 	switch right.Type() {
 	case object.INTEGER_OBJECT:
+		if right, ok := right.(*object.BigInteger); ok {
+			return evalBigIntegerPrefixExpression(node.Operator, right)
+		}
 		return evalIntegerPrefixExpression(node.Operator, right.(*object.Integer))
-	case object.BOOLEAN_OBJECT:
-		return evalBooleanPrefixExpression(node.Operator, right.(*object.Boolean))
-	case object.NULL_OBJECT:
-		return evalNullPrefixExpression(node.Operator)
-	case object.STRING_OBJECT:
-		return evalStringPrefixExpression(node.Operator, right.(*object.String))
-	case object.ARRAY_OBJECT:
-		return evalArrayPrefixExpression(node.Operator, right.(*object.Array))
-	case object.HASH_OBJECT:
-		return evalHashPrefixExpression(node.Operator, right.(*object.Hash))
+	case object.FLOAT_OBJECT:
+		return evalFloatPrefixExpression(node.Operator, right.(*object.Float))
 	default:
 		return toErrorObject("unknown operation: %s%s", node.Operator, right.Type())
 	}
@@ -237,57 +526,31 @@ func evalPrefixExpression(node *ast.PrefixExpression, env *object.Environment) o
 
 func evalIntegerPrefixExpression(operator string, right *object.Integer) object.Object {
 	switch operator {
-	case "!":
-		return toBooleanObject(right == ZERO)
 	case "-":
+		if right.Value == math.MinInt64 {
+			return toBigIntegerObject(new(big.Int).Neg(big.NewInt(right.Value)))
+		}
 		return toIntegerObject(-right.Value)
 	default:
 		return toErrorObject("unknown operation: %s%s", operator, object.INTEGER_OBJECT)
 	}
 }
 
-func evalBooleanPrefixExpression(operator string, right *object.Boolean) object.Object {
-	switch operator {
-	case "!":
-		return toBooleanObject(right == FALSE)
-	default:
-		return toErrorObject("unknown operation: %s%s", operator, object.BOOLEAN_OBJECT)
-	}
-}
-
-func evalNullPrefixExpression(operator string) object.Object {
-	switch operator {
-	case "!":
-		return TRUE
-	default:
-		return toErrorObject("unknown operation: %s%s", operator, object.NULL_OBJECT)
-	}
-}
-
-func evalStringPrefixExpression(operator string, right *object.String) object.Object {
-	switch operator {
-	case "!":
-		return toBooleanObject(right == EMPTY_STRING)
-	default:
-		return toErrorObject("unknown operation: %s%s", operator, object.STRING_OBJECT)
-	}
-}
-
-func evalArrayPrefixExpression(operator string, right *object.Array) object.Object {
+func evalBigIntegerPrefixExpression(operator string, right *object.BigInteger) object.Object {
 	switch operator {
-	case "!":
-		return toBooleanObject(right == EMPTY_ARRAY)
+	case "-":
+		return toBigIntegerObject(new(big.Int).Neg(right.Value))
 	default:
-		return toErrorObject("unknown operation: %s%s", operator, object.ARRAY_OBJECT)
+		return toErrorObject("unknown operation: %s%s", operator, object.INTEGER_OBJECT)
 	}
 }
 
-func evalHashPrefixExpression(operator string, right *object.Hash) object.Object {
+func evalFloatPrefixExpression(operator string, right *object.Float) object.Object {
 	switch operator {
-	case "!":
-		return toBooleanObject(right == EMPTY_HASH)
+	case "-":
+		return toFloatObject(-right.Value)
 	default:
-		return toErrorObject("unknown operation: %s%s", operator, object.HASH_OBJECT)
+		return toErrorObject("unknown operation: %s%s", operator, object.FLOAT_OBJECT)
 	}
 }
 
@@ -298,46 +561,104 @@ func evalInfixExpression(node *ast.InfixExpression, env *object.Environment) obj
 		return left
 	}
 
+	if node.Operator == "&&" || node.Operator == "||" {
+		return evalLogicalInfixExpression(node.Operator, left, node.Right, env)
+	}
+
 	right := Eval(node.Right, env)
 
 	if isError(right) {
 		return right
 	}
 
+	return evalInfixOperation(node.Operator, left, right)
+}
+
+func evalLogicalInfixExpression(operator string, left object.Object, right ast.Expression, env *object.Environment) object.Object {
+	switch operator {
+	case "&&":
+		if !left.Truthy() {
+			return left
+		}
+	case "||":
+		if left.Truthy() {
+			return left
+		}
+	}
+
+	return Eval(right, env)
+}
+
+func evalInfixOperation(operator string, left, right object.Object) object.Object {
 	switch {
 	case left.Type() == object.INTEGER_OBJECT && right.Type() == object.INTEGER_OBJECT:
-		return evalIntegerInfixExpression(node.Operator, left.(*object.Integer), right.(*object.Integer))
+		l, lok := left.(*object.Integer)
+		r, rok := right.(*object.Integer)
+		if lok && rok {
+			return evalIntegerInfixExpression(operator, l, r)
+		}
+		return evalBigIntegerInfixExpression(operator, toBigInt(left), toBigInt(right))
+	case isNumber(left) && isNumber(right):
+		return evalMixedInfixExpression(operator, left, right)
 	case left.Type() == object.BOOLEAN_OBJECT && right.Type() == object.BOOLEAN_OBJECT:
-		return evalBooleanInfixExpression(node.Operator, left.(*object.Boolean), right.(*object.Boolean))
-	case left.Type() == object.NULL_OBJECT && right.Type() == object.NULL_OBJECT:
-		return evalNullInfixExpression(node.Operator)
-	case left.Type() == object.FUNCTION_OBJECT && right.Type() == object.FUNCTION_OBJECT:
-		return evalFunctionInfixExpression(node.Operator, left.(*object.Function), right.(*object.Function))
+		return evalBooleanInfixExpression(operator, left.(*object.Boolean), right.(*object.Boolean))
 	case left.Type() == object.STRING_OBJECT && right.Type() == object.STRING_OBJECT:
-		return evalStringInfixExpression(node.Operator, left.(*object.String), right.(*object.String))
-	case left.Type() == object.ARRAY_OBJECT && right.Type() == object.ARRAY_OBJECT:
-		return evalArrayInfixExpression(node.Operator, left.(*object.Array), right.(*object.Array))
-	case left.Type() == object.HASH_OBJECT && right.Type() == object.HASH_OBJECT:
-		return evalHashInfixExpression(node.Operator, left.(*object.Hash), right.(*object.Hash))
+		return evalStringInfixExpression(operator, left.(*object.String), right.(*object.String))
+	case left.Type() == object.ARRAY_OBJECT && right.Type() == object.ARRAY_OBJECT && operator == "+":
+		return evalArrayConcatExpression(left.(*object.Array), right.(*object.Array))
+	case left.Type() == object.ARRAY_OBJECT && right.Type() == object.INTEGER_OBJECT && operator == "*":
+		return evalArrayRepeatExpression(left.(*object.Array), right)
+	case left.Type() == object.STRING_OBJECT && right.Type() == object.INTEGER_OBJECT && operator == "*":
+		return evalStringRepeatExpression(left.(*object.String), right)
+	case operator == "==":
+		return toBooleanObject(isEqual(left, right))
+	case operator == "!=":
+		return toBooleanObject(!isEqual(left, right))
 	default:
-		return toErrorObject("unknown operation: %s %s %s", left.Type(), node.Operator, right.Type())
+		return toErrorObject("unknown operation: %s %s %s", left.Type(), operator, right.Type())
 	}
 }
 
+// Operations that would overflow int64 fall through to the big.Int
+// implementation, which demotes the result again when it fits.
 func evalIntegerInfixExpression(operator string, left, right *object.Integer) object.Object {
 	switch operator {
 	case "+":
-		return toIntegerObject(left.Value + right.Value)
+		sum := left.Value + right.Value
+		if (sum > left.Value) == (right.Value > 0) {
+			return toIntegerObject(sum)
+		}
 	case "-":
-		return toIntegerObject(left.Value - right.Value)
+		diff := left.Value - right.Value
+		if (diff < left.Value) == (right.Value > 0) {
+			return toIntegerObject(diff)
+		}
 	case "*":
-		return toIntegerObject(left.Value * right.Value)
+		product := left.Value * right.Value
+		if left.Value == 0 || (product/left.Value == right.Value && !(left.Value == -1 && right.Value == math.MinInt64)) {
+			return toIntegerObject(product)
+		}
 	case "/":
-		return toIntegerObject(left.Value / right.Value)
+		if right.Value == 0 {
+			return toErrorObject("division by zero: %d / 0", left.Value)
+		}
+		if !(left.Value == math.MinInt64 && right.Value == -1) {
+			return toIntegerObject(left.Value / right.Value)
+		}
+	case "%":
+		if right.Value == 0 {
+			return toErrorObject("division by zero: %d %% 0", left.Value)
+		}
+		return toIntegerObject(left.Value % right.Value)
+	case "**":
 	case "<":
 		return toBooleanObject(left.Value < right.Value)
 	case ">":
 		return toBooleanObject(left.Value > right.Value)
+	case "<=":
+		return toBooleanObject(left.Value <= right.Value)
+	case ">=":
+		return toBooleanObject(left.Value >= right.Value)
 	case "==":
 		return toBooleanObject(left.Value == right.Value)
 	case "!=":
@@ -345,109 +666,357 @@ func evalIntegerInfixExpression(operator string, left, right *object.Integer) ob
 	default:
 		return toErrorObject("unknown operation: %s %s %s", object.INTEGER_OBJECT, operator, object.INTEGER_OBJECT)
 	}
+
+	return evalBigIntegerInfixExpression(operator, big.NewInt(left.Value), big.NewInt(right.Value))
 }
 
-func evalBooleanInfixExpression(operator string, left, right *object.Boolean) object.Object {
+func evalBigIntegerInfixExpression(operator string, left, right *big.Int) object.Object {
 	switch operator {
+	case "+":
+		return toBigIntegerObject(new(big.Int).Add(left, right))
+	case "-":
+		return toBigIntegerObject(new(big.Int).Sub(left, right))
+	case "*":
+		return toBigIntegerObject(new(big.Int).Mul(left, right))
+	case "/":
+		if right.Sign() == 0 {
+			return toErrorObject("division by zero: %s / 0", left.String())
+		}
+		return toBigIntegerObject(new(big.Int).Quo(left, right))
+	case "%":
+		if right.Sign() == 0 {
+			return toErrorObject("division by zero: %s %% 0", left.String())
+		}
+		return toBigIntegerObject(new(big.Int).Rem(left, right))
+	case "**":
+		if right.Sign() < 0 {
+			return toErrorObject("negative exponent: %s ** %s", left.String(), right.String())
+		}
+		if left.CmpAbs(big.NewInt(1)) > 0 && (!right.IsInt64() || right.Int64() > MAX_POWER_BITS/int64(left.BitLen())) {
+			return toErrorObject("power result too large: %s ** %s", left.String(), right.String())
+		}
+		return toBigIntegerObject(new(big.Int).Exp(left, right, nil))
+	case "<":
+		return toBooleanObject(left.Cmp(right) < 0)
+	case ">":
+		return toBooleanObject(left.Cmp(right) > 0)
+	case "<=":
+		return toBooleanObject(left.Cmp(right) <= 0)
+	case ">=":
+		return toBooleanObject(left.Cmp(right) >= 0)
 	case "==":
-		return toBooleanObject(left == right)
+		return toBooleanObject(left.Cmp(right) == 0)
 	case "!=":
-		return toBooleanObject(left != right)
+		return toBooleanObject(left.Cmp(right) != 0)
 	default:
-		return toErrorObject("unknown operation: %s %s %s", object.BOOLEAN_OBJECT, operator, object.BOOLEAN_OBJECT)
+		return toErrorObject("unknown operation: %s %s %s", object.INTEGER_OBJECT, operator, object.INTEGER_OBJECT)
 	}
 }
 
-func evalNullInfixExpression(operator string) object.Object {
+// Comparisons between an integer and a float are exact, matching the hash keys
+// of numbers, while arithmetic is done in float64.
+func evalMixedInfixExpression(operator string, left, right object.Object) object.Object {
+	l, r := toFloat(left), toFloat(right)
+	if math.IsNaN(l) || math.IsNaN(r) {
+		return evalFloatInfixExpression(operator, l, r)
+	}
+
+	cmp := toBigFloat(left).Cmp(toBigFloat(right))
+
 	switch operator {
+	case "<":
+		return toBooleanObject(cmp < 0)
+	case ">":
+		return toBooleanObject(cmp > 0)
+	case "<=":
+		return toBooleanObject(cmp <= 0)
+	case ">=":
+		return toBooleanObject(cmp >= 0)
 	case "==":
-		return TRUE
+		return toBooleanObject(cmp == 0)
 	case "!=":
-		return FALSE
+		return toBooleanObject(cmp != 0)
 	default:
-		return toErrorObject("unknown operation: %s %s %s", object.NULL_OBJECT, operator, object.NULL_OBJECT)
+		return evalFloatInfixExpression(operator, l, r)
 	}
 }
 
-func evalFunctionInfixExpression(operator string, left, right *object.Function) object.Object {
+func evalFloatInfixExpression(operator string, left, right float64) object.Object {
 	switch operator {
+	case "+":
+		return toFloatObject(left + right)
+	case "-":
+		return toFloatObject(left - right)
+	case "*":
+		return toFloatObject(left * right)
+	case "/":
+		if right == 0 {
+			return toErrorObject("division by zero: %s / 0", toFloatObject(left).Inspect())
+		}
+		return toFloatObject(left / right)
+	case "%":
+		if right == 0 {
+			return toErrorObject("division by zero: %s %% 0", toFloatObject(left).Inspect())
+		}
+		return toFloatObject(math.Mod(left, right))
+	case "**":
+		return toFloatObject(math.Pow(left, right))
+	case "<":
+		return toBooleanObject(left < right)
+	case ">":
+		return toBooleanObject(left > right)
+	case "<=":
+		return toBooleanObject(left <= right)
+	case ">=":
+		return toBooleanObject(left >= right)
 	case "==":
 		return toBooleanObject(left == right)
 	case "!=":
 		return toBooleanObject(left != right)
 	default:
-		return toErrorObject("unknown operation: %s %s %s", object.FUNCTION_OBJECT, operator, object.FUNCTION_OBJECT)
+		return toErrorObject("unknown operation: %s %s %s", object.FLOAT_OBJECT, operator, object.FLOAT_OBJECT)
 	}
 }
 
-func evalStringInfixExpression(operator string, left, right *object.String) object.Object {
+func evalBooleanInfixExpression(operator string, left, right *object.Boolean) object.Object {
 	switch operator {
-	case "+":
-		return toStringObject(left.Value + right.Value)
 	case "==":
 		return toBooleanObject(left.Value == right.Value)
 	case "!=":
 		return toBooleanObject(left.Value != right.Value)
 	default:
-		return toErrorObject("unknown operation: %s %s %s", object.STRING_OBJECT, operator, object.STRING_OBJECT)
+		return toErrorObject("unknown operation: %s %s %s", object.BOOLEAN_OBJECT, operator, object.BOOLEAN_OBJECT)
 	}
 }
 
-func evalArrayInfixExpression(operator string, left, right *object.Array) object.Object {
+func evalStringInfixExpression(operator string, left, right *object.String) object.Object {
 	switch operator {
+	case "+":
+		return toStringObject(left.Value + right.Value)
+	case "<":
+		return toBooleanObject(left.Value < right.Value)
+	case ">":
+		return toBooleanObject(left.Value > right.Value)
+	case "<=":
+		return toBooleanObject(left.Value <= right.Value)
+	case ">=":
+		return toBooleanObject(left.Value >= right.Value)
 	case "==":
-		return toBooleanObject(left == right)
+		return toBooleanObject(left.Value == right.Value)
 	case "!=":
-		return toBooleanObject(left != right)
+		return toBooleanObject(left.Value != right.Value)
 	default:
-		return toErrorObject("unknown operation: %s %s %s", object.ARRAY_OBJECT, operator, object.ARRAY_OBJECT)
+		return toErrorObject("unknown operation: %s %s %s", object.STRING_OBJECT, operator, object.STRING_OBJECT)
 	}
 }
 
-func evalHashInfixExpression(operator string, left, right *object.Hash) object.Object {
-	switch operator {
-	case "==":
-		return toBooleanObject(left == right)
-	case "!=":
-		return toBooleanObject(left != right)
-	default:
-		return toErrorObject("unknown operation: %s %s %s", object.HASH_OBJECT, operator, object.HASH_OBJECT)
+func evalStringRepeatExpression(left *object.String, right object.Object) object.Object {
+	if count, ok := right.(*object.BigInteger); ok && count.Value.Sign() > 0 {
+		if left.Value == "" {
+			return EMPTY_STRING
+		}
+		return toErrorObject("repeat result too large: %s * %s", object.STRING_OBJECT, right.Inspect())
 	}
-}
 
-func evalIfExpression(node *ast.IfExpression, env *object.Environment) object.Object {
-	condition := Eval(node.Condition, env)
+	count, ok := right.(*object.Integer)
+	if !ok || count.Value < 0 {
+		return toErrorObject("invalid repeat count: %s * %s", object.STRING_OBJECT, right.Inspect())
+	}
 
-	if isError(condition) {
-		return condition
+	if left.Value == "" || count.Value == 0 {
+		return EMPTY_STRING
 	}
 
-	if isTruthy(condition) {
-		return Eval(node.Consequence, env)
-	} else if node.Alternative != nil {
-		return Eval(node.Alternative, env)
+	if int64(len(left.Value)) > MAX_REPEAT_LENGTH/count.Value {
+		return toErrorObject("repeat result too large: %s * %s", object.STRING_OBJECT, right.Inspect())
 	}
 
-	return NULL
+	return toStringObject(strings.Repeat(left.Value, int(count.Value)))
 }
 
-func evalCallExpression(node *ast.CallExpression, env *object.Environment) object.Object {
-	if node.Function.TokenLexeme() == "quote" && len(node.Arguments) == 1 {
-		return toQuoteObject(node.Arguments[0], env)
-	}
+// Concatenation and repetition build a new array; the operands are left
+// untouched, although repeated elements are shared rather than copied.
+func evalArrayConcatExpression(left, right *object.Array) object.Object {
+	elements := make([]object.Object, 0, len(left.Elements)+len(right.Elements))
+	elements = append(elements, left.Elements...)
+	elements = append(elements, right.Elements...)
 
-	function := Eval(node.Function, env)
+	return toArrayObject(elements)
+}
 
-	if isError(function) {
-		return function
+func evalArrayRepeatExpression(left *object.Array, right object.Object) object.Object {
+	if count, ok := right.(*object.BigInteger); ok && count.Value.Sign() > 0 {
+		if len(left.Elements) == 0 {
+			return toArrayObject([]object.Object{})
+		}
+		return toErrorObject("repeat result too large: %s * %s", object.ARRAY_OBJECT, right.Inspect())
 	}
 
-	args := []object.Object{}
+	count, ok := right.(*object.Integer)
+	if !ok || count.Value < 0 {
+		return toErrorObject("invalid repeat count: %s * %s", object.ARRAY_OBJECT, right.Inspect())
+	}
 
-	for _, arg := range node.Arguments {
-		result := Eval(arg, env)
+	if len(left.Elements) == 0 || count.Value == 0 {
+		return toArrayObject([]object.Object{})
+	}
 
-		if isError(result) {
+	if int64(len(left.Elements)) > MAX_REPEAT_LENGTH/count.Value {
+		return toErrorObject("repeat result too large: %s * %s", object.ARRAY_OBJECT, right.Inspect())
+	}
+
+	elements := make([]object.Object, 0, len(left.Elements)*int(count.Value))
+	for i := int64(0); i < count.Value; i = i + 1 {
+		elements = append(elements, left.Elements...)
+	}
+
+	return toArrayObject(elements)
+}
+
+func evalAssignExpression(node *ast.AssignExpression, env *object.Environment) object.Object {
+	value := Eval(node.Value, env)
+
+	if isError(value) {
+		return value
+	}
+
+	switch target := node.Target.(type) {
+	case *ast.Identifier:
+		return evalIdentifierAssignExpression(node.Operator, target, value, env)
+	case *ast.IndexExpression:
+		return evalIndexAssignExpression(node.Operator, target, value, env)
+	default:
+		return toErrorObject("invalid assignment target: %s", node.Target.String())
+	}
+}
+
+func evalIdentifierAssignExpression(operator string, target *ast.Identifier, value object.Object, env *object.Environment) object.Object {
+	if operator != "=" {
+		current, ok := env.Get(target.Value)
+		if !ok {
+			return toErrorObject("undefined reference: %s", target.Value)
+		}
+
+		value = evalInfixOperation(strings.TrimSuffix(operator, "="), current, value)
+
+		if isError(value) {
+			return value
+		}
+	}
+
+	if !env.Assign(target.Value, value) {
+		return toErrorObject("undefined reference: %s", target.Value)
+	}
+
+	return value
+}
+
+func evalIndexAssignExpression(operator string, target *ast.IndexExpression, value object.Object, env *object.Environment) object.Object {
+	indexable := Eval(target.Struct, env)
+
+	if isError(indexable) {
+		return indexable
+	}
+
+	index := Eval(target.Index, env)
+
+	if isError(index) {
+		return index
+	}
+
+	if operator != "=" {
+		current := evalIndexOperation(indexable, index, env.Strict())
+
+		if isError(current) {
+			return current
+		}
+
+		value = evalInfixOperation(strings.TrimSuffix(operator, "="), current, value)
+
+		if isError(value) {
+			return value
+		}
+	}
+
+	switch {
+	case indexable.Type() == object.ARRAY_OBJECT && index.Type() == object.INTEGER_OBJECT:
+		return evalArrayIndexAssignExpression(indexable.(*object.Array), index, value)
+	case indexable.Type() == object.HASH_OBJECT:
+		return evalHashIndexAssignExpression(indexable.(*object.Hash), index, value)
+	default:
+		return toErrorObject("unknown operation: %s[%s]", indexable.Type(), index.Type())
+	}
+}
+
+// Arrays and hashes are mutable in place, so every binding referring to the
+// same object observes the update.
+func evalArrayIndexAssignExpression(array *object.Array, index object.Object, value object.Object) object.Object {
+	i, ok := toIndex(index, len(array.Elements))
+	if !ok {
+		return toErrorObject("index out of range: ARRAY[%s] with length %d", index.Inspect(), len(array.Elements))
+	}
+
+	array.Elements[i] = value
+
+	return value
+}
+
+func evalHashIndexAssignExpression(hash *object.Hash, index object.Object, value object.Object) object.Object {
+	hashable, ok := index.(object.Hashable)
+	if !ok {
+		return toErrorObject("invalid type: %s is not hashable", index.Type())
+	}
+
+	hash.Set(hashable, value)
+
+	return value
+}
+
+func evalIfExpression(node *ast.IfExpression, env *object.Environment) object.Object {
+	condition := Eval(node.Condition, env)
+
+	if isError(condition) {
+		return condition
+	}
+
+	if condition.Truthy() {
+		return Eval(node.Consequence, env)
+	} else if node.Alternative != nil {
+		return Eval(node.Alternative, env)
+	}
+
+	return NULL
+}
+
+func evalCallExpression(node *ast.CallExpression, env *object.Environment) object.Object {
+	result := evalTailCallExpression(node, env)
+
+	if tailCall, ok := result.(*object.TailCall); ok {
+		return evalFunctionCallExpression(tailCall.Function, tailCall.Arguments, tailCall.Token)
+	}
+
+	return result
+}
+
+// evalTailCallExpression evaluates the callee and arguments of a call, calling
+// builtins directly but returning a tail call for functions.
+func evalTailCallExpression(node *ast.CallExpression, env *object.Environment) object.Object {
+	if node.Function.TokenLexeme() == "quote" && len(node.Arguments) == 1 {
+		return toQuoteObject(node.Arguments[0], env)
+	}
+
+	function := Eval(node.Function, env)
+
+	if isError(function) {
+		return function
+	}
+
+	args := []object.Object{}
+
+	for _, arg := range node.Arguments {
+		result := Eval(arg, env)
+
+		if isError(result) {
 			return result
 		}
 
@@ -456,7 +1025,15 @@ func evalCallExpression(node *ast.CallExpression, env *object.Environment) objec
 
 	switch fn := function.(type) {
 	case *object.Function:
-		return evalFunctionCallExpression(fn, args)
+		if err := checkArity(fn, len(args)); err != nil {
+			return err
+		}
+
+		return &object.TailCall{
+			Token:     node.Token,
+			Function:  fn,
+			Arguments: args,
+		}
 	case *object.Builtin:
 		return fn.Fn(args...)
 	default:
@@ -464,26 +1041,148 @@ func evalCallExpression(node *ast.CallExpression, env *object.Environment) objec
 	}
 }
 
-func evalFunctionCallExpression(fn *object.Function, args []object.Object) object.Object {
+// evalFunctionCallExpression runs fn and the tail calls it returns in a loop.
+func evalFunctionCallExpression(fn *object.Function, args []object.Object, call token.Token) object.Object {
+	env := fn.Env
+
+	env.PushCall(functionName(fn))
+	defer env.PopCall()
+
+	if len(env.Calls()) > env.MaxDepth() {
+		err := toErrorObject("maximum recursion depth exceeded (%d): %s", env.MaxDepth(), callChain(env.Calls()))
+		locate(err, call.Line, call.Column)
+		return err
+	}
+
+	for {
+		enclosed, err := extendFunctionEnvironment(fn, args)
+		if err != nil {
+			return pushFrame(err, fn, call)
+		}
+
+		result := evalTailBlockStatement(fn.Body, enclosed)
+
+		if isError(result) {
+			return pushFrame(result, fn, call)
+		}
+
+		if returnValue, ok := result.(*object.ReturnValue); ok {
+			return returnValue.Value
+		}
+
+		tailCall, ok := result.(*object.TailCall)
+		if !ok {
+			return result
+		}
+
+		env.PopCall()
+		env.PushCall(functionName(tailCall.Function))
+
+		fn, args = tailCall.Function, tailCall.Arguments
+	}
+}
+
+// locate attributes an error not yet attributed to a deeper node to line and column.
+func locate(obj object.Object, line, column int) {
+	if err, ok := obj.(*object.Error); ok && err.Line == 0 {
+		err.Line = line
+		err.Column = column
+	}
+}
+
+func pushFrame(obj object.Object, fn *object.Function, call token.Token) object.Object {
+	err := obj.(*object.Error)
+	err.Stack = append(err.Stack, object.Frame{
+		Function: functionName(fn),
+		Line:     call.Line,
+		Column:   call.Column,
+	})
+	return err
+}
+
+func functionName(fn *object.Function) string {
+	if fn.Name == "" {
+		return "<anonymous>"
+	}
+	return fn.Name
+}
+
+// callChain lists the functions on a call stack in the order they were first
+// called, so deep recursion through a few functions reads as a short chain.
+func callChain(calls []string) string {
+	seen := map[string]bool{}
+	names := []string{}
+
+	for _, name := range calls {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, " -> ")
+}
+
+func extendFunctionEnvironment(fn *object.Function, args []object.Object) (*object.Environment, object.Object) {
 	enclosed := object.NewEnclosedEnvironment(fn.Env)
 
 	for i, param := range fn.Parameters {
-		enclosed.Set(param.Value, args[i])
+		if i < len(args) {
+			enclosed.Set(param.Value, args[i])
+			continue
+		}
+
+		value := Eval(fn.Defaults[i], enclosed)
+
+		if isError(value) {
+			return nil, value
+		}
+
+		enclosed.Set(param.Value, value)
 	}
 
-	result := Eval(fn.Body, enclosed)
+	if fn.Rest != nil {
+		rest := []object.Object{}
+		if len(args) > len(fn.Parameters) {
+			rest = append(rest, args[len(fn.Parameters):]...)
+		}
+		enclosed.Set(fn.Rest.Value, toArrayObject(rest))
+	}
 
-	if returnValue, ok := result.(*object.ReturnValue); ok {
-		return returnValue.Value
+	return enclosed, nil
+}
+
+// checkArity reports an error if argc arguments cannot be bound to the
+// parameters of fn. Parameters with a default value may be left out, and any
+// number of extra arguments is accepted when fn has a rest parameter.
+func checkArity(fn *object.Function, argc int) object.Object {
+	required := 0
+	for i := range fn.Parameters {
+		if i >= len(fn.Defaults) || fn.Defaults[i] == nil {
+			required = i + 1
+		}
 	}
 
-	return result
+	name := functionName(fn)
+
+	switch {
+	case fn.Rest != nil && argc < required:
+		return toErrorObject("invalid argument count in call to `%s`: found %d want at least %d", name, argc, required)
+	case fn.Rest == nil && required == len(fn.Parameters) && argc != required:
+		return toErrorObject("invalid argument count in call to `%s`: found %d want %d", name, argc, required)
+	case fn.Rest == nil && (argc < required || argc > len(fn.Parameters)):
+		return toErrorObject("invalid argument count in call to `%s`: found %d want %d to %d", name, argc, required, len(fn.Parameters))
+	}
+
+	return nil
 }
 
-func evalUnquoteCallExpression(node ast.Node, env *object.Environment) ast.Node {
-	return ast.Modify(node, func(node ast.Node) ast.Node {
+func evalUnquoteCallExpression(node ast.Node, env *object.Environment) (ast.Node, object.Object) {
+	var err object.Object
+
+	node = ast.Modify(node, func(node ast.Node) ast.Node {
 		callExpr, ok := node.(*ast.CallExpression)
-		if !ok {
+		if !ok || err != nil {
 			return node
 		}
 
@@ -491,8 +1190,15 @@ func evalUnquoteCallExpression(node ast.Node, env *object.Environment) ast.Node
 			return node
 		}
 
-		return toASTNode(Eval(callExpr.Arguments[0], env))
+		unquoted, e := toASTNode(Eval(callExpr.Arguments[0], env), map[object.Object]bool{})
+		if e != nil {
+			err = e
+			return node
+		}
+		return unquoted
 	})
+
+	return node, err
 }
 
 func evalIndexExpression(node *ast.IndexExpression, env *object.Environment) object.Object {
@@ -508,31 +1214,179 @@ func evalIndexExpression(node *ast.IndexExpression, env *object.Environment) obj
 		return index
 	}
 
+	return evalIndexOperation(indexable, index, env.Strict())
+}
+
+// Out of range indexes and missing keys evaluate to NULL unless strict is set,
+// in which case they are errors naming the offending index.
+func evalIndexOperation(indexable, index object.Object, strict bool) object.Object {
 	switch {
 	case indexable.Type() == object.ARRAY_OBJECT && index.Type() == object.INTEGER_OBJECT:
-		return evalArrayIndexExpression(indexable.(*object.Array), index.(*object.Integer))
+		return evalArrayIndexExpression(indexable.(*object.Array), index, strict)
+	case indexable.Type() == object.STRING_OBJECT && index.Type() == object.INTEGER_OBJECT:
+		return evalStringIndexExpression(indexable.(*object.String), index, strict)
 	case indexable.Type() == object.HASH_OBJECT:
-		return evalHashIndexExpression(indexable.(*object.Hash), index)
+		return evalHashIndexExpression(indexable.(*object.Hash), index, strict)
 	default:
 		return toErrorObject("unknown operation: %s[%s]", indexable.Type(), index.Type())
 	}
 }
 
-func evalArrayIndexExpression(array *object.Array, index *object.Integer) object.Object {
-	if index.Value >= 0 && index.Value < int64(len(array.Elements)) {
-		return array.Elements[index.Value]
+func evalArrayIndexExpression(array *object.Array, index object.Object, strict bool) object.Object {
+	if i, ok := toIndex(index, len(array.Elements)); ok {
+		return array.Elements[i]
+	}
+
+	if strict {
+		return toErrorObject("index out of range: ARRAY[%s] with length %d", index.Inspect(), len(array.Elements))
 	}
 	return NULL
 }
 
-func evalHashIndexExpression(hash *object.Hash, index object.Object) object.Object {
+// Strings are indexed by rune, which is O(n) unless the string is all ASCII.
+func evalStringIndexExpression(str *object.String, index object.Object, strict bool) object.Object {
+	length := str.Length()
+
+	if i, ok := toIndex(index, length); ok {
+		if length == len(str.Value) {
+			return toStringObject(str.Value[i : i+1])
+		}
+
+		value := str.Value
+		for n := 0; n < i; n = n + 1 {
+			_, size := utf8.DecodeRuneInString(value)
+			value = value[size:]
+		}
+
+		_, size := utf8.DecodeRuneInString(value)
+		return toStringObject(value[:size])
+	}
+
+	if strict {
+		return toErrorObject("index out of range: STRING[%s] with length %d", index.Inspect(), length)
+	}
+	return NULL
+}
+
+func evalSliceExpression(node *ast.SliceExpression, env *object.Environment) object.Object {
+	sliceable := Eval(node.Struct, env)
+
+	if isError(sliceable) {
+		return sliceable
+	}
+
+	var start, end object.Object
+
+	if node.Start != nil {
+		start = Eval(node.Start, env)
+
+		if isError(start) {
+			return start
+		}
+	}
+
+	if node.End != nil {
+		end = Eval(node.End, env)
+
+		if isError(end) {
+			return end
+		}
+	}
+
+	switch sliceable := sliceable.(type) {
+	case *object.Array:
+		i, j, ok := sliceBounds(start, end, len(sliceable.Elements))
+		if !ok {
+			break
+		}
+
+		elements := make([]object.Object, j-i)
+		copy(elements, sliceable.Elements[i:j])
+		return toArrayObject(elements)
+	case *object.String:
+		offsets := []int{}
+		for offset := range sliceable.Value {
+			offsets = append(offsets, offset)
+		}
+		offsets = append(offsets, len(sliceable.Value))
+
+		i, j, ok := sliceBounds(start, end, len(offsets)-1)
+		if !ok {
+			break
+		}
+
+		return toStringObject(sliceable.Value[offsets[i]:offsets[j]])
+	}
+
+	return toErrorObject("unknown operation: %s[%s:%s]", sliceable.Type(), sliceBoundType(start), sliceBoundType(end))
+}
+
+// sliceBounds resolves the optional start and end of a slice over length
+// elements, counting negative bounds from the end and clamping both into
+// range so that the slice is empty rather than an error when they cross.
+func sliceBounds(start, end object.Object, length int) (int, int, bool) {
+	i, ok := sliceBound(start, length, 0)
+	if !ok {
+		return 0, 0, false
+	}
+
+	j, ok := sliceBound(end, length, length)
+	if !ok {
+		return 0, 0, false
+	}
+
+	if j < i {
+		j = i
+	}
+
+	return i, j, true
+}
+
+func sliceBound(bound object.Object, length int, fallback int) (int, bool) {
+	switch bound := bound.(type) {
+	case nil:
+		return fallback, true
+	case *object.Integer:
+		i := bound.Value
+		if i < 0 {
+			i = i + int64(length)
+		}
+
+		if i < 0 {
+			return 0, true
+		} else if i > int64(length) {
+			return length, true
+		}
+		return int(i), true
+	case *object.BigInteger:
+		if bound.Value.Sign() < 0 {
+			return 0, true
+		}
+		return length, true
+	default:
+		return 0, false
+	}
+}
+
+func sliceBoundType(bound object.Object) object.ObjectType {
+	if bound == nil {
+		return ""
+	}
+	return bound.Type()
+}
+
+func evalHashIndexExpression(hash *object.Hash, index object.Object, strict bool) object.Object {
 	hashable, ok := index.(object.Hashable)
 	if !ok {
 		return toErrorObject("unknown operation: HASH[%s]", index.Type())
 	}
 
-	if result, ok := hash.Pairs[hashable.HashKey()]; ok {
-		return result.Value
+	if result, ok := hash.Get(hashable); ok {
+		return result
+	}
+
+	if strict {
+		return toErrorObject("key not found: HASH[%s]", inspectKey(index))
 	}
 	return NULL
 }
@@ -546,6 +1400,21 @@ func toIntegerObject(val int64) object.Object {
 	}
 }
 
+func toBigIntegerObject(val *big.Int) object.Object {
+	if val.IsInt64() {
+		return toIntegerObject(val.Int64())
+	}
+	return &object.BigInteger{
+		Value: val,
+	}
+}
+
+func toFloatObject(val float64) object.Object {
+	return &object.Float{
+		Value: val,
+	}
+}
+
 func toBooleanObject(val bool) object.Object {
 	if val {
 		return TRUE
@@ -559,6 +1428,21 @@ func toErrorObject(format string, args ...any) object.Object {
 	}
 }
 
+func toCaughtObject(err *object.Error) object.Object {
+	value := err.Value
+	if value == nil {
+		value = NULL
+	}
+
+	hash := &object.Hash{}
+	hash.Set(&object.String{Value: "message"}, toStringObject(err.Message))
+	hash.Set(&object.String{Value: "line"}, toIntegerObject(int64(err.Line)))
+	hash.Set(&object.String{Value: "column"}, toIntegerObject(int64(err.Column)))
+	hash.Set(&object.String{Value: "value"}, value)
+
+	return hash
+}
+
 func toStringObject(val string) object.Object {
 	if val == "" {
 		return EMPTY_STRING
@@ -569,31 +1453,23 @@ func toStringObject(val string) object.Object {
 }
 
 func toArrayObject(elems []object.Object) object.Object {
-	if len(elems) == 0 {
-		return EMPTY_ARRAY
-	}
 	return &object.Array{
 		Elements: elems,
 	}
 }
 
-func toHashObject(pairs map[object.HashKey]object.HashPair) object.Object {
-	if len(pairs) == 0 {
-		return EMPTY_HASH
-	}
-	return &object.Hash{
-		Pairs: pairs,
+func toQuoteObject(node ast.Node, env *object.Environment) object.Object {
+	node, err := evalUnquoteCallExpression(node, env)
+	if err != nil {
+		return err
 	}
-}
 
-func toQuoteObject(node ast.Node, env *object.Environment) object.Object {
-	node = evalUnquoteCallExpression(node, env)
 	return &object.Quote{
 		Node: node,
 	}
 }
 
-func toASTNode(obj object.Object) ast.Node {
+func toASTNode(obj object.Object, seen map[object.Object]bool) (ast.Node, object.Object) {
 	switch obj := obj.(type) {
 	case *object.Integer:
 		return &ast.IntegerLiteral{
@@ -602,7 +1478,23 @@ func toASTNode(obj object.Object) ast.Node {
 				Lexeme: fmt.Sprintf("%d", obj.Value),
 			},
 			Value: obj.Value,
-		}
+		}, nil
+	case *object.BigInteger:
+		return &ast.BigIntegerLiteral{
+			Token: token.Token{
+				Type:   token.INT,
+				Lexeme: obj.Value.String(),
+			},
+			Value: obj.Value,
+		}, nil
+	case *object.Float:
+		return &ast.FloatLiteral{
+			Token: token.Token{
+				Type:   token.FLOAT,
+				Lexeme: obj.Inspect(),
+			},
+			Value: obj.Value,
+		}, nil
 	case *object.Boolean:
 		var ttype token.TokenType
 		if obj.Value {
@@ -616,7 +1508,7 @@ func toASTNode(obj object.Object) ast.Node {
 				Lexeme: fmt.Sprintf("%t", obj.Value),
 			},
 			Value: obj.Value,
-		}
+		}, nil
 	case *object.Function:
 		return &ast.FunctionLiteral{
 			Token: token.Token{
@@ -624,8 +1516,10 @@ func toASTNode(obj object.Object) ast.Node {
 				Lexeme: "fn",
 			},
 			Parameters: obj.Parameters,
+			Defaults:   obj.Defaults,
+			Rest:       obj.Rest,
 			Body:       obj.Body,
-		}
+		}, nil
 	case *object.String:
 		return &ast.StringLiteral{
 			Token: token.Token{
@@ -633,12 +1527,22 @@ func toASTNode(obj object.Object) ast.Node {
 				Lexeme: obj.Value,
 			},
 			Value: obj.Value,
-		}
+		}, nil
 	case *object.Array:
+		if seen[obj] {
+			return nil, toErrorObject("cannot unquote cyclic value: %s", object.ARRAY_OBJECT)
+		}
+		seen[obj] = true
+		defer delete(seen, obj)
+
 		elems := []ast.Expression{}
 		for _, o := range obj.Elements {
-			elem, _ := toASTNode(o).(ast.Expression)
+			node, err := toASTNode(o, seen)
+			if err != nil {
+				return nil, err
+			}
 
+			elem, _ := node.(ast.Expression)
 			elems = append(elems, elem)
 		}
 		return &ast.ArrayLiteral{
@@ -647,36 +1551,203 @@ func toASTNode(obj object.Object) ast.Node {
 				Lexeme: "[",
 			},
 			Elements: elems,
-		}
+		}, nil
 	case *object.Hash:
-		pairs := make(map[ast.Expression]ast.Expression)
+		if seen[obj] {
+			return nil, toErrorObject("cannot unquote cyclic value: %s", object.HASH_OBJECT)
+		}
+		seen[obj] = true
+		defer delete(seen, obj)
+
+		keys := []ast.Expression{}
+		values := []ast.Expression{}
 		for _, pair := range obj.Pairs {
-			key, _ := toASTNode(pair.Key).(ast.Expression)
-			value, _ := toASTNode(pair.Value).(ast.Expression)
+			keyNode, err := toASTNode(pair.Key, seen)
+			if err != nil {
+				return nil, err
+			}
+
+			valueNode, err := toASTNode(pair.Value, seen)
+			if err != nil {
+				return nil, err
+			}
+
+			key, _ := keyNode.(ast.Expression)
+			value, _ := valueNode.(ast.Expression)
 
-			pairs[key] = value
+			keys = append(keys, key)
+			values = append(values, value)
 		}
 		return &ast.HashLiteral{
 			Token: token.Token{
 				Type:   token.LBRACE,
 				Lexeme: "{",
 			},
-			Pairs: pairs,
-		}
+			Keys:   keys,
+			Values: values,
+		}, nil
 	case *object.Quote:
-		return obj.Node
+		return obj.Node, nil
+	case *object.Error:
+		return nil, obj
 	default:
-		return nil
+		return nil, nil
 	}
 }
 
-func isTruthy(obj object.Object) bool {
-	switch obj {
-	case ZERO, FALSE, NULL, EMPTY_STRING, EMPTY_ARRAY, EMPTY_HASH:
-		return false
+func isNumber(obj object.Object) bool {
+	return obj.Type() == object.INTEGER_OBJECT || obj.Type() == object.FLOAT_OBJECT
+}
+
+func toFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.BigInteger:
+		f, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return f
+	case *object.Float:
+		return obj.Value
+	default:
+		return 0
+	}
+}
+
+func toBigFloat(obj object.Object) *big.Float {
+	switch obj := obj.(type) {
+	case *object.Float:
+		return big.NewFloat(obj.Value)
 	default:
+		return new(big.Float).SetInt(toBigInt(obj))
+	}
+}
+
+func toBigInt(obj object.Object) *big.Int {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return big.NewInt(obj.Value)
+	case *object.BigInteger:
+		return obj.Value
+	default:
+		return new(big.Int)
+	}
+}
+
+// isEqual reports whether left and right are structurally equal: numbers by
+// value across INTEGER and FLOAT, arrays element-wise, hashes key-wise and
+// functions by parameters, body and closure. Values of different types are
+// never equal.
+func isEqual(left, right object.Object) bool {
+	return equal(left, right, map[[2]object.Object]bool{})
+}
+
+// equal tracks the pairs of containers it is already comparing in seen, so a
+// cycle back to one of them is assumed equal instead of recursing forever.
+func equal(left, right object.Object, seen map[[2]object.Object]bool) bool {
+	if isNumber(left) && isNumber(right) {
+		return evalInfixOperation("==", left, right) == TRUE
+	}
+
+	if left.Type() != right.Type() {
+		return false
+	}
+
+	switch left := left.(type) {
+	case *object.Boolean:
+		return left.Value == right.(*object.Boolean).Value
+	case *object.Null:
 		return true
+	case *object.String:
+		return left.Value == right.(*object.String).Value
+	case *object.Array:
+		right := right.(*object.Array)
+		if left == right {
+			return true
+		}
+
+		pair := [2]object.Object{left, right}
+		if seen[pair] {
+			return true
+		}
+		seen[pair] = true
+
+		if len(left.Elements) != len(right.Elements) {
+			return false
+		}
+
+		for i := range left.Elements {
+			if !equal(left.Elements[i], right.Elements[i], seen) {
+				return false
+			}
+		}
+		return true
+	case *object.Hash:
+		right := right.(*object.Hash)
+		if left == right {
+			return true
+		}
+
+		pair := [2]object.Object{left, right}
+		if seen[pair] {
+			return true
+		}
+		seen[pair] = true
+
+		if len(left.Pairs) != len(right.Pairs) {
+			return false
+		}
+
+		for _, lpair := range left.Pairs {
+			key, ok := lpair.Key.(object.Hashable)
+			if !ok {
+				return false
+			}
+
+			rvalue, ok := right.Get(key)
+			if !ok || !equal(lpair.Value, rvalue, seen) {
+				return false
+			}
+		}
+		return true
+	case *object.Function:
+		right := right.(*object.Function)
+		return left == right || left.Env == right.Env && left.Inspect() == right.Inspect()
+	case *object.Macro:
+		right := right.(*object.Macro)
+		return left == right || left.Env == right.Env && left.Inspect() == right.Inspect()
+	case *object.Quote:
+		return left.Node.String() == right.(*object.Quote).Node.String()
+	case *object.Error:
+		return left.Message == right.(*object.Error).Message
+	default:
+		return left == right
+	}
+}
+
+// toIndex resolves an integer index into a sequence of length elements,
+// counting negative indexes from the end, and reports whether it is in range.
+func toIndex(index object.Object, length int) (int, bool) {
+	i, ok := index.(*object.Integer)
+	if !ok {
+		return 0, false
+	}
+
+	value := i.Value
+	if value < 0 {
+		value = value + int64(length)
+	}
+
+	if value < 0 || value >= int64(length) {
+		return 0, false
+	}
+	return int(value), true
+}
+
+func inspectKey(key object.Object) string {
+	if key.Type() == object.STRING_OBJECT {
+		return strconv.Quote(key.Inspect())
 	}
+	return key.Inspect()
 }
 
 func isError(obj object.Object) bool {
@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	"runtime/debug"
 	"testing"
 
 	"github.com/eugene-whitaker/writing-an-interpreter-in-go/lexer"
@@ -16,6 +17,14 @@ type IntegerTest int64
 
 func (iot IntegerTest) object() {}
 
+type BigIntegerTest string
+
+func (bit BigIntegerTest) object() {}
+
+type FloatTest float64
+
+func (ft FloatTest) object() {}
+
 type BooleanTest bool
 
 func (bt BooleanTest) object() {}
@@ -403,6 +412,83 @@ func TestEval(t *testing.T) {
 			"let first = 10; let second = 10; let third = 10; let func = fn(first) { let second = 20; first + second + third; }; func(20) + first + second;",
 			IntegerTest(70),
 		},
+		{
+			"let add = fn(x, y) { x + y; }; add(1);",
+			ErrorTest{
+				"invalid argument count in call to `add`: found 1 want 2",
+			},
+		},
+		{
+			"let add = fn(x, y) { x + y; }; add(1, 2, 3);",
+			ErrorTest{
+				"invalid argument count in call to `add`: found 3 want 2",
+			},
+		},
+		{
+			"fn(x) { x; }();",
+			ErrorTest{
+				"invalid argument count in call to `<anonymous>`: found 0 want 1",
+			},
+		},
+		{
+			"let add = fn(x, y = 10) { x + y; }; add(1);",
+			IntegerTest(11),
+		},
+		{
+			"let add = fn(x, y = 10) { x + y; }; add(1, 2);",
+			IntegerTest(3),
+		},
+		{
+			"let add = fn(x, y = x * 2) { x + y; }; add(3);",
+			IntegerTest(9),
+		},
+		{
+			"let add = fn(x, y = 10) { x + y; }; add();",
+			ErrorTest{
+				"invalid argument count in call to `add`: found 0 want 1 to 2",
+			},
+		},
+		{
+			"let add = fn(x, y = 10) { x + y; }; add(1, 2, 3);",
+			ErrorTest{
+				"invalid argument count in call to `add`: found 3 want 1 to 2",
+			},
+		},
+		{
+			"let f = fn(x = z) { x; }; f();",
+			ErrorTest{
+				"undefined reference: z",
+			},
+		},
+		{
+			"let f = fn(first, ...rest) { rest; }; f(1, 2, 3);",
+			ArrayTest{
+				IntegerTest(2),
+				IntegerTest(3),
+			},
+		},
+		{
+			"let f = fn(first, ...rest) { rest; }; f(1);",
+			ArrayTest{},
+		},
+		{
+			"let f = fn(first, second = 2, ...rest) { [first, second, rest]; }; f(1);",
+			ArrayTest{
+				IntegerTest(1),
+				IntegerTest(2),
+				ArrayTest{},
+			},
+		},
+		{
+			"let f = fn(first, ...rest) { rest; }; f();",
+			ErrorTest{
+				"invalid argument count in call to `f`: found 0 want at least 1",
+			},
+		},
+		{
+			"let f = fn(x, y = 1, ...rest) { x; }; \"${f}\";",
+			StringTest("fn(x, y = 1, ...rest) {\nx\n}"),
+		},
 		{
 			"\"hello world\";",
 			StringTest("hello world"),
@@ -546,6 +632,10 @@ func TestEval(t *testing.T) {
 		},
 		{
 			"[1, 2, 3][-1]",
+			IntegerTest(3),
+		},
+		{
+			"[1, 2, 3][-4]",
 			NullTest{},
 		},
 		{
@@ -669,178 +759,1718 @@ func TestEval(t *testing.T) {
 				"(8 + (4 + 4))",
 			},
 		},
-	}
-
-	for i, test := range tests {
-		l := lexer.NewLexer(test.input)
-		p := parser.NewParser(l)
-		program := p.ParseProgram()
-		env := object.NewEnvironment()
-		eval := Eval(program, env)
-
-		if !testObject(t, i, test.input, eval, test.test) {
-			continue
-		}
-	}
-}
-
-func testObject(t *testing.T, idx int, input string, obj object.Object, test ObjectTest) bool {
-	switch test := test.(type) {
-	case IntegerTest:
-		return testInteger(t, idx, input, obj, int64(test))
-	case BooleanTest:
-		return testBoolean(t, idx, input, obj, bool(test))
-	case NullTest:
-		return testNull(t, idx, input, obj)
-	case ErrorTest:
-		return testError(t, idx, input, obj, test.message)
-	case FunctionTest:
-		return testFunction(t, idx, input, obj, test.parameters, test.body)
-	case StringTest:
-		return testString(t, idx, input, obj, string(test))
-	case ArrayTest:
-		return testArray(t, idx, input, obj, []ObjectTest(test))
-	case HashTest:
-		return testHash(t, idx, input, obj, map[object.HashKey]ObjectTest(test))
-	case QuoteTest:
-		return testQuote(t, idx, input, obj, test.node)
-	}
-	t.Errorf("test[%d] - %q ==> unexpected type. actual: %T", idx, input, test)
-	return false
-}
-
-func testInteger(t *testing.T, idx int, input string, obj object.Object, value int64) bool {
-	result, ok := obj.(*object.Integer)
-	if !ok {
-		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Integer{}, obj)
-		return false
-	}
-
-	if value != result.Value {
-		t.Errorf("test[%d] - %q - result.Value ==> expected: %d actual: %d", idx, input, value, result.Value)
-		return false
-	}
-
-	return true
-}
-
-func testBoolean(t *testing.T, idx int, input string, obj object.Object, value bool) bool {
-	result, ok := obj.(*object.Boolean)
-	if !ok {
-		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Boolean{}, obj)
-		return false
-	}
-
-	if value != result.Value {
-		t.Errorf("test[%d] - %q - result.Value ==> expected: %t actual: %t", idx, input, value, result.Value)
-		return false
-	}
-
-	return true
-}
-
-func testNull(t *testing.T, idx int, input string, obj object.Object) bool {
-	if NULL != obj {
-		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Null{}, obj)
-		return false
-	}
-
-	return true
-}
-
-func testError(t *testing.T, idx int, input string, obj object.Object, message string) bool {
-	result, ok := obj.(*object.Error)
-	if !ok {
-		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Error{}, obj)
-		return false
-	}
-
-	if message != result.Message {
-		t.Errorf("test[%d] - %q - result.Message ==> expected: %q actual: %q", idx, input, message, result.Message)
-		return false
-	}
-
-	return true
-}
-
-func testFunction(t *testing.T, idx int, input string, obj object.Object, params []string, body string) bool {
-	result, ok := obj.(*object.Function)
-	if !ok {
-		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Function{}, obj)
-		return false
-	}
-
-	if len(params) != len(result.Parameters) {
-		t.Errorf("test[%d] - %q - len(result.Parameters) ==> expected: %d actual: %d", idx, input, len(params), len(result.Parameters))
-		return false
-	}
-
-	for i, param := range result.Parameters {
-		if params[i] != param.String() {
-			t.Errorf("test[%d] - %q - result.Parameters[%d].String() ==> expected: %q actual: %q", idx, input, i, params[i], param)
-			return false
-		}
-	}
-
-	actual := result.Body.String()
-	if body != actual {
-		t.Errorf("test[%d] - %q - result.Body.String() ==> expected: %q actual: %q", idx, input, body, actual)
-		return false
-	}
-
-	return true
-}
-
-func testString(t *testing.T, idx int, input string, obj object.Object, value string) bool {
-	result, ok := obj.(*object.String)
-	if !ok {
-		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.String{}, obj)
-		return false
-	}
-
-	if value != result.Value {
-		t.Errorf("test[%d] - %q - result.Value ==> expected: %q actual: %q", idx, input, value, result.Value)
-		return false
-	}
-
-	return true
-}
-
-func testArray(t *testing.T, idx int, input string, obj object.Object, tests []ObjectTest) bool {
-	result, ok := obj.(*object.Array)
-	if !ok {
-		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Array{}, obj)
-		return false
-	}
-
-	if len(tests) != len(result.Elements) {
-		t.Errorf("test[%d] - %q - len(result.Elements) ==> expected: %d actual: %d", idx, input, len(tests), len(result.Elements))
-		return false
-	}
-
-	for i, elem := range result.Elements {
-		if !testObject(t, idx, input, elem, tests[i]) {
-			return false
-		}
-	}
-
-	return true
-}
-
-func testHash(t *testing.T, idx int, input string, obj object.Object, tests map[object.HashKey]ObjectTest) bool {
-	result, ok := obj.(*object.Hash)
-	if !ok {
-		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Hash{}, obj)
-		return false
-	}
-
-	if len(tests) != len(result.Pairs) {
-		t.Errorf("test[%d] - %q - len(result.Pairs) ==> expected: %d actual: %d", idx, input, len(tests), len(result.Pairs))
-		return false
-	}
+		{
+			"while (false) { 1; }",
+			NullTest{},
+		},
+		{
+			"while (true) { break; }; 5;",
+			IntegerTest(5),
+		},
+		{
+			"let f = fn(n) { while (true) { if (n > 3) { return n; }; let n = n + 1; }; }; f(0);",
+			IntegerTest(4),
+		},
+		{
+			"let f = fn(n) { let c = 0; while (n < 10) { let n = n + 1; if (n > 5) { continue; }; let c = c + 1; }; c; }; f(0);",
+			IntegerTest(5),
+		},
+		{
+			"let f = fn() { let sum = 0; for (let i = 0; ; let i = i + 1) { let sum = sum + i; if (i == 4) { return sum; }; }; }; f();",
+			IntegerTest(10),
+		},
+		{
+			"let f = fn() { for (let i = 0; i < 10; let i = i + 1) { if (i < 7) { continue; }; return i; }; }; f();",
+			IntegerTest(7),
+		},
+		{
+			"for (let i = 0; i < 3; let i = i + 1) { i; }; i;",
+			ErrorTest{
+				"undefined reference: i",
+			},
+		},
+		{
+			"for (;;) { for (;;) { break; }; break; }; 1;",
+			IntegerTest(1),
+		},
+		{
+			"while (true) { 1 + true; }",
+			ErrorTest{
+				"unknown operation: INTEGER + BOOLEAN",
+			},
+		},
+		{
+			"let x = 1; x = 2; x;",
+			IntegerTest(2),
+		},
+		{
+			"let x = 1; x = 2;",
+			IntegerTest(2),
+		},
+		{
+			"let x = 1; let y = 1; x = y = 3; x + y;",
+			IntegerTest(6),
+		},
+		{
+			"x = 1;",
+			ErrorTest{
+				"undefined reference: x",
+			},
+		},
+		{
+			"x += 1;",
+			ErrorTest{
+				"undefined reference: x",
+			},
+		},
+		{
+			"let x = 10; x += 5; x -= 3; x *= 2; x /= 4; x;",
+			IntegerTest(6),
+		},
+		{
+			"let s = \"hello\"; s += \" world\"; s;",
+			StringTest("hello world"),
+		},
+		{
+			"let x = 1; x += true;",
+			ErrorTest{
+				"unknown operation: INTEGER + BOOLEAN",
+			},
+		},
+		{
+			"let counter = fn() { let count = 0; fn() { count += 1; }; }; let next = counter(); next(); next(); next();",
+			IntegerTest(3),
+		},
+		{
+			"let x = 1; let f = fn() { let x = 2; x = 3; }; f(); x;",
+			IntegerTest(1),
+		},
+		{
+			"let sum = 0; for (let i = 0; i < 5; i += 1) { sum += i; }; sum;",
+			IntegerTest(10),
+		},
+		{
+			"let i = 0; while (i < 10) { i = i + 1; }; i;",
+			IntegerTest(10),
+		},
+		{
+			"let a = [1, 2, 3]; a[1] = 5; a;",
+			ArrayTest(
+				[]ObjectTest{
+					IntegerTest(1),
+					IntegerTest(5),
+					IntegerTest(3),
+				},
+			),
+		},
+		{
+			"let a = [1, 2, 3]; a[0] += 10;",
+			IntegerTest(11),
+		},
+		{
+			"let a = [1, 2, 3]; a[3] = 4;",
+			ErrorTest{
+				"index out of range: ARRAY[3] with length 3",
+			},
+		},
+		{
+			"let a = [1, 2, 3]; a[-1] = 4; a;",
+			ArrayTest{
+				IntegerTest(1),
+				IntegerTest(2),
+				IntegerTest(4),
+			},
+		},
+		{
+			"let a = [1, 2, 3]; a[-4] = 4;",
+			ErrorTest{
+				"index out of range: ARRAY[-4] with length 3",
+			},
+		},
+		{
+			"let a = [1, 2]; let b = a; a[0] = 9; b[0];",
+			IntegerTest(9),
+		},
+		{
+			"let a = [[1, 2], [3, 4]]; a[1][0] = 7; a[1];",
+			ArrayTest(
+				[]ObjectTest{
+					IntegerTest(7),
+					IntegerTest(4),
+				},
+			),
+		},
+		{
+			"let h = {}; h[\"a\"] = 1; h[\"a\"];",
+			IntegerTest(1),
+		},
+		{
+			"let h = {}; h[\"a\"] = 1; let g = {}; g[\"a\"];",
+			NullTest{},
+		},
+		{
+			"let h = {\"a\": 1}; h[\"a\"] *= 5; h[\"a\"];",
+			IntegerTest(5),
+		},
+		{
+			"let h = {}; h[\"a\"] += 1;",
+			ErrorTest{
+				"unknown operation: NULL + INTEGER",
+			},
+		},
+		{
+			"let h = {}; h[[]] = 1;",
+			ErrorTest{
+				"invalid type: ARRAY is not hashable",
+			},
+		},
+		{
+			"let s = \"abc\"; s[0] = \"x\";",
+			ErrorTest{
+				"unknown operation: STRING[INTEGER]",
+			},
+		},
+		{
+			"let h = {}; let f = fn(h) { h[1] = true; }; f(h); !h;",
+			BooleanTest(false),
+		},
+		{
+			"!{};",
+			BooleanTest(true),
+		},
+		{
+			"![];",
+			BooleanTest(true),
+		},
+		{
+			"true && true;",
+			BooleanTest(true),
+		},
+		{
+			"true && false;",
+			BooleanTest(false),
+		},
+		{
+			"false || true;",
+			BooleanTest(true),
+		},
+		{
+			"false || false;",
+			BooleanTest(false),
+		},
+		{
+			"1 && 2;",
+			IntegerTest(2),
+		},
+		{
+			"0 && 2;",
+			IntegerTest(0),
+		},
+		{
+			"\"\" || \"default\";",
+			StringTest("default"),
+		},
+		{
+			"[1] || 2;",
+			ArrayTest(
+				[]ObjectTest{
+					IntegerTest(1),
+				},
+			),
+		},
+		{
+			"false && undefined;",
+			BooleanTest(false),
+		},
+		{
+			"true || undefined;",
+			BooleanTest(true),
+		},
+		{
+			"true && undefined;",
+			ErrorTest{
+				"undefined reference: undefined",
+			},
+		},
+		{
+			"let calls = 0; let f = fn() { calls += 1; true; }; f() || f(); false && f(); calls;",
+			IntegerTest(1),
+		},
+		{
+			"1 < 2 && 2 < 3;",
+			BooleanTest(true),
+		},
+		{
+			"1 <= 1;",
+			BooleanTest(true),
+		},
+		{
+			"2 <= 1;",
+			BooleanTest(false),
+		},
+		{
+			"1 >= 1;",
+			BooleanTest(true),
+		},
+		{
+			"1 >= 2;",
+			BooleanTest(false),
+		},
+		{
+			"7 % 3;",
+			IntegerTest(1),
+		},
+		{
+			"-7 % 3;",
+			IntegerTest(-1),
+		},
+		{
+			"2 ** 10;",
+			IntegerTest(1024),
+		},
+		{
+			"2 ** 3 ** 2;",
+			IntegerTest(512),
+		},
+		{
+			"-2 ** 2;",
+			IntegerTest(-4),
+		},
+		{
+			"5 ** 0;",
+			IntegerTest(1),
+		},
+		{
+			"2 ** -1;",
+			ErrorTest{
+				"negative exponent: 2 ** -1",
+			},
+		},
+		{
+			"10 ** 10 ** 10;",
+			ErrorTest{
+				"power result too large: 10 ** 10000000000",
+			},
+		},
+		{
+			"2 ** (2 ** 64);",
+			ErrorTest{
+				"power result too large: 2 ** 18446744073709551616",
+			},
+		},
+		{
+			"1 ** (2 ** 64);",
+			IntegerTest(1),
+		},
+		{
+			"-1 ** 10 ** 10;",
+			IntegerTest(-1),
+		},
+		{
+			"0 ** 10 ** 10;",
+			IntegerTest(0),
+		},
+		{
+			"1 / 0;",
+			ErrorTest{
+				"division by zero: 1 / 0",
+			},
+		},
+		{
+			"1 % 0;",
+			ErrorTest{
+				"division by zero: 1 % 0",
+			},
+		},
+		{
+			"let x = 1; x /= 0;",
+			ErrorTest{
+				"division by zero: 1 / 0",
+			},
+		},
+		{
+			"3.5;",
+			FloatTest(3.5),
+		},
+		{
+			"-2.5;",
+			FloatTest(-2.5),
+		},
+		{
+			"1.5 + 1.5;",
+			FloatTest(3),
+		},
+		{
+			"1 + 0.5;",
+			FloatTest(1.5),
+		},
+		{
+			"0.5 * 4;",
+			FloatTest(2),
+		},
+		{
+			"1 / 4.0;",
+			FloatTest(0.25),
+		},
+		{
+			"7.5 % 2;",
+			FloatTest(1.5),
+		},
+		{
+			"2 ** 0.5 ** 2;",
+			FloatTest(1.189207115002721),
+		},
+		{
+			"1.0 / 0;",
+			ErrorTest{
+				"division by zero: 1.0 / 0",
+			},
+		},
+		{
+			"1 < 1.5;",
+			BooleanTest(true),
+		},
+		{
+			"2.0 == 2;",
+			BooleanTest(true),
+		},
+		{
+			"2.5 >= 3;",
+			BooleanTest(false),
+		},
+		{
+			"9007199254740993 == 9007199254740992.0;",
+			BooleanTest(false),
+		},
+		{
+			"9007199254740993 > 9007199254740992.0;",
+			BooleanTest(true),
+		},
+		{
+			"9007199254740992.0 < 9007199254740993;",
+			BooleanTest(true),
+		},
+		{
+			"9007199254740992 == 9007199254740992.0;",
+			BooleanTest(true),
+		},
+		{
+			"{9007199254740993: 1}[9007199254740992.0];",
+			NullTest{},
+		},
+		{
+			"[9007199254740993] == [9007199254740992.0];",
+			BooleanTest(false),
+		},
+		{
+			"2 ** 64 + 1 > 18446744073709551616.0;",
+			BooleanTest(true),
+		},
+		{
+			"let nan = float(\"nan\"); [nan == 1, nan != 1, nan < 1, float(\"inf\") > 2 ** 64];",
+			ArrayTest{BooleanTest(false), BooleanTest(true), BooleanTest(false), BooleanTest(true)},
+		},
+		{
+			"!0.0;",
+			BooleanTest(true),
+		},
+		{
+			"if (0.0) { 1 } else { 2 };",
+			IntegerTest(2),
+		},
+		{
+			"{1: \"one\"}[1.0];",
+			StringTest("one"),
+		},
+		{
+			"{1.5: \"half\"}[1.5];",
+			StringTest("half"),
+		},
+		{
+			"1.5 + true;",
+			ErrorTest{
+				"unknown operation: FLOAT + BOOLEAN",
+			},
+		},
+		{
+			"int(3.9);",
+			IntegerTest(3),
+		},
+		{
+			"int(-3.9);",
+			IntegerTest(-3),
+		},
+		{
+			"int(\"42\");",
+			IntegerTest(42),
+		},
+		{
+			"int(\"4.2\");",
+			ErrorTest{
+				"invalid argument value in call to `int`: cannot convert \"4.2\" to INTEGER",
+			},
+		},
+		{
+			"int(true);",
+			ErrorTest{
+				"invalid argument types in call to `int`: found (BOOLEAN) want (INTEGER) or (FLOAT) or (STRING)",
+			},
+		},
+		{
+			"float(2);",
+			FloatTest(2),
+		},
+		{
+			"float(\"1e-3\");",
+			FloatTest(0.001),
+		},
+		{
+			"float(\"abc\");",
+			ErrorTest{
+				"invalid argument value in call to `float`: cannot convert \"abc\" to FLOAT",
+			},
+		},
+		{
+			"float(1, 2);",
+			ErrorTest{
+				"invalid argument count in call to `float`: found (INTEGER, INTEGER) want (INTEGER) or (FLOAT) or (STRING)",
+			},
+		},
+		{
+			"quote(unquote(1.5 + 1));",
+			QuoteTest{
+				"2.5",
+			},
+		},
+		{
+			"9223372036854775807 + 1;",
+			BigIntegerTest("9223372036854775808"),
+		},
+		{
+			"-9223372036854775807 - 2;",
+			BigIntegerTest("-9223372036854775809"),
+		},
+		{
+			"4294967296 * 4294967296;",
+			BigIntegerTest("18446744073709551616"),
+		},
+		{
+			"-(-9223372036854775807 - 1);",
+			BigIntegerTest("9223372036854775808"),
+		},
+		{
+			"(-9223372036854775807 - 1) / -1;",
+			BigIntegerTest("9223372036854775808"),
+		},
+		{
+			"let fact = fn(n) { if (n < 2) { 1 } else { n * fact(n - 1) } }; fact(25);",
+			BigIntegerTest("15511210043330985984000000"),
+		},
+		{
+			"18446744073709551616;",
+			BigIntegerTest("18446744073709551616"),
+		},
+		{
+			"0xFFFF_FFFF_FFFF_FFFF + 0b1;",
+			BigIntegerTest("18446744073709551616"),
+		},
+		{
+			"2 ** 64 - 2 ** 64 + 1;",
+			IntegerTest(1),
+		},
+		{
+			"2 ** 64 / 2 ** 60;",
+			IntegerTest(16),
+		},
+		{
+			"2 ** 64 % 10;",
+			IntegerTest(6),
+		},
+		{
+			"2 ** 64 > 2 ** 63;",
+			BooleanTest(true),
+		},
+		{
+			"2 ** 64 == 18446744073709551616;",
+			BooleanTest(true),
+		},
+		{
+			"2 ** 64 < 1.5;",
+			BooleanTest(false),
+		},
+		{
+			"2 ** 64 / 0;",
+			ErrorTest{
+				"division by zero: 18446744073709551616 / 0",
+			},
+		},
+		{
+			"{2 ** 64: 1}[18446744073709551616];",
+			IntegerTest(1),
+		},
+		{
+			"{18446744073709551616: 1}[18446744073709551616.0];",
+			IntegerTest(1),
+		},
+		{
+			"[1, 2, 3][2 ** 64];",
+			NullTest{},
+		},
+		{
+			"int(\"18446744073709551616\");",
+			BigIntegerTest("18446744073709551616"),
+		},
+		{
+			"int(1e20);",
+			BigIntegerTest("100000000000000000000"),
+		},
+		{
+			"float(2 ** 64);",
+			FloatTest(18446744073709551616),
+		},
+		{
+			"quote(unquote(2 ** 64));",
+			QuoteTest{
+				"18446744073709551616",
+			},
+		},
+		{
+			"let name = \"monkey\"; let age = 9; \"hello ${name}, you are ${age + 1}\";",
+			StringTest("hello monkey, you are 10"),
+		},
+		{
+			"\"${[1, true]} ${{\"a\": 1.5}} ${fn(x) { x }(\"${1}\")}\";",
+			StringTest("[1, true] {a:1.5} 1"),
+		},
+		{
+			"\"\\${x}\";",
+			StringTest("${x}"),
+		},
+		{
+			"\"${x}\";",
+			ErrorTest{
+				"undefined reference: x",
+			},
+		},
+		{
+			"quote(\"a ${unquote(1 + 2)} \\${b}\");",
+			QuoteTest{
+				"\"a ${3} \\${b}\"",
+			},
+		},
+		{
+			"let données = 1; let x2 = données + 1; x2;",
+			IntegerTest(2),
+		},
+		{
+			"let empty? = fn(a) { len(a) == 0 }; empty?([]);",
+			BooleanTest(true),
+		},
+		{
+			"let reset! = fn(a) { a[0] = 0; a }; reset!([5, 6])[0];",
+			IntegerTest(0),
+		},
+		{
+			"len(\"héllo, 世界\");",
+			IntegerTest(9),
+		},
+		{
+			"bytes_len(\"héllo, 世界\");",
+			IntegerTest(14),
+		},
+		{
+			"bytes_len([]);",
+			ErrorTest{
+				"invalid argument types in call to `bytes_len`: found (ARRAY) want (STRING)",
+			},
+		},
+		{
+			"\"héllo, 世界\"[1];",
+			StringTest("é"),
+		},
+		{
+			"\"héllo, 世界\"[8];",
+			StringTest("界"),
+		},
+		{
+			"\"héllo\"[5];",
+			NullTest{},
+		},
+		{
+			"\"héllo\"[-4];",
+			StringTest("é"),
+		},
+		{
+			"\"héllo\"[-6];",
+			NullTest{},
+		},
+		{
+			"\"hello\"[1];",
+			StringTest("e"),
+		},
+		{
+			"\"hello\"[-1];",
+			StringTest("o"),
+		},
+		{
+			"\"hello\"[5];",
+			NullTest{},
+		},
+		{
+			"chars(\"a世\\u{1F600}\");",
+			ArrayTest{
+				StringTest("a"),
+				StringTest("世"),
+				StringTest("\U0001F600"),
+			},
+		},
+		{
+			"chars(\"\");",
+			ArrayTest{},
+		},
+		{
+			"[1, 2, 3, 4, 5][1:3];",
+			ArrayTest{
+				IntegerTest(2),
+				IntegerTest(3),
+			},
+		},
+		{
+			"[1, 2, 3, 4, 5][-2:];",
+			ArrayTest{
+				IntegerTest(4),
+				IntegerTest(5),
+			},
+		},
+		{
+			"[1, 2, 3, 4, 5][:-3];",
+			ArrayTest{
+				IntegerTest(1),
+				IntegerTest(2),
+			},
+		},
+		{
+			"[1, 2, 3][-10:10];",
+			ArrayTest{
+				IntegerTest(1),
+				IntegerTest(2),
+				IntegerTest(3),
+			},
+		},
+		{
+			"[1, 2, 3][2:1];",
+			ArrayTest{},
+		},
+		{
+			"let a = [1, 2, 3]; let b = a[:]; b[0] = 9; a[0];",
+			IntegerTest(1),
+		},
+		{
+			"\"héllo, 世界\"[1:4];",
+			StringTest("éll"),
+		},
+		{
+			"\"héllo, 世界\"[-2:];",
+			StringTest("世界"),
+		},
+		{
+			"\"héllo\"[:0];",
+			StringTest(""),
+		},
+		{
+			"[1, 2][\"a\":];",
+			ErrorTest{
+				"unknown operation: ARRAY[STRING:]",
+			},
+		},
+		{
+			"{}[:1];",
+			ErrorTest{
+				"unknown operation: HASH[:INTEGER]",
+			},
+		},
+		{
+			"[1, 2] == [1, 2];",
+			BooleanTest(true),
+		},
+		{
+			"[1, [2, \"a\"]] == [1.0, [2, \"a\"]];",
+			BooleanTest(true),
+		},
+		{
+			"[1, 2] != [1, 2, 3];",
+			BooleanTest(true),
+		},
+		{
+			"{\"a\": [1], 2: [][0]} == {2: [][0], \"a\": [1]};",
+			BooleanTest(true),
+		},
+		{
+			"{\"a\": 1} == {\"a\": 2};",
+			BooleanTest(false),
+		},
+		{
+			"{\"a\": 1} == {\"b\": 1};",
+			BooleanTest(false),
+		},
+		{
+			"let a = [1]; a[0] = a; let b = [1]; b[0] = b; a == b;",
+			BooleanTest(true),
+		},
+		{
+			"let a = [1]; a[0] = a; let b = [[2]]; a == b;",
+			BooleanTest(false),
+		},
+		{
+			"let h = {}; h[\"self\"] = h; let g = {}; g[\"self\"] = g; h == g;",
+			BooleanTest(true),
+		},
+		{
+			"let f = fn(x) { x + 1 }; let g = fn(x) { x + 1 }; f == g;",
+			BooleanTest(true),
+		},
+		{
+			"fn(x) { x + 1 } == fn(y) { y + 1 };",
+			BooleanTest(false),
+		},
+		{
+			"let make = fn(n) { fn(x) { x + n } }; make(1) == make(1);",
+			BooleanTest(false),
+		},
+		{
+			"len == len;",
+			BooleanTest(true),
+		},
+		{
+			"1 == \"1\";",
+			BooleanTest(false),
+		},
+		{
+			"[1] != {};",
+			BooleanTest(true),
+		},
+		{
+			"[][0] == false;",
+			BooleanTest(false),
+		},
+		{
+			"[1] < [2];",
+			ErrorTest{
+				"unknown operation: ARRAY < ARRAY",
+			},
+		},
+		{
+			"!fn(x) { x };",
+			BooleanTest(false),
+		},
+		{
+			"!len;",
+			BooleanTest(false),
+		},
+		{
+			"[1, 2] + [3] + [];",
+			ArrayTest{
+				IntegerTest(1),
+				IntegerTest(2),
+				IntegerTest(3),
+			},
+		},
+		{
+			"let a = [1]; let b = a + [2]; a;",
+			ArrayTest{
+				IntegerTest(1),
+			},
+		},
+		{
+			"[0, \"x\"] * 2;",
+			ArrayTest{
+				IntegerTest(0),
+				StringTest("x"),
+				IntegerTest(0),
+				StringTest("x"),
+			},
+		},
+		{
+			"[1] * 0;",
+			ArrayTest{},
+		},
+		{
+			"[1] * -1;",
+			ErrorTest{
+				"invalid repeat count: ARRAY * -1",
+			},
+		},
+		{
+			"\"ab\" * 3;",
+			StringTest("ababab"),
+		},
+		{
+			"\"ab\" * (2 ** 64);",
+			ErrorTest{
+				"repeat result too large: STRING * 18446744073709551616",
+			},
+		},
+		{
+			"\"a\" * 2 ** 70;",
+			ErrorTest{
+				"repeat result too large: STRING * 1180591620717411303424",
+			},
+		},
+		{
+			"\"\" * 2 ** 70;",
+			StringTest(""),
+		},
+		{
+			"\"a\" * -(2 ** 70);",
+			ErrorTest{
+				"invalid repeat count: STRING * -1180591620717411303424",
+			},
+		},
+		{
+			"[1] * 2 ** 70;",
+			ErrorTest{
+				"repeat result too large: ARRAY * 1180591620717411303424",
+			},
+		},
+		{
+			"[] * 2 ** 70;",
+			ArrayTest{},
+		},
+		{
+			"let s = \"-\"; s *= 2; s;",
+			StringTest("--"),
+		},
+		{
+			"\"ab\" * 9223372036854775807;",
+			ErrorTest{
+				"repeat result too large: STRING * 9223372036854775807",
+			},
+		},
+		{
+			"\"\" * 9223372036854775807;",
+			StringTest(""),
+		},
+		{
+			"\"ab\" * 0;",
+			StringTest(""),
+		},
+		{
+			"[1, 2] * 9223372036854775807;",
+			ErrorTest{
+				"repeat result too large: ARRAY * 9223372036854775807",
+			},
+		},
+		{
+			"[] * 9223372036854775807;",
+			ArrayTest{},
+		},
+		{
+			"[1] * 100000000;",
+			ErrorTest{
+				"repeat result too large: ARRAY * 100000000",
+			},
+		},
+		{
+			"\"apple\" < \"banana\";",
+			BooleanTest(true),
+		},
+		{
+			"\"b\" > \"abc\";",
+			BooleanTest(true),
+		},
+		{
+			"\"abc\" <= \"abc\";",
+			BooleanTest(true),
+		},
+		{
+			"\"ab\" >= \"abc\";",
+			BooleanTest(false),
+		},
+		{
+			"\"a\" - \"b\";",
+			ErrorTest{
+				"unknown operation: STRING - STRING",
+			},
+		},
+		{
+			"[1] - [1];",
+			ErrorTest{
+				"unknown operation: ARRAY - ARRAY",
+			},
+		},
+		{
+			"2 * [1];",
+			ErrorTest{
+				"unknown operation: INTEGER * ARRAY",
+			},
+		},
+		{
+			"\"${{\"c\": 1, \"a\": 2, \"b\": 3, 10: 4, true: 5}}\";",
+			StringTest("{c:1, a:2, b:3, 10:4, true:5}"),
+		},
+		{
+			"let h = {\"x\": 1, \"y\": 2}; h[\"z\"] = 3; h[\"x\"] = 4; \"${h}\";",
+			StringTest("{x:4, y:2, z:3}"),
+		},
+		{
+			"let log = []; let f = fn(x) { log = log + [x]; x }; {f(\"a\"): f(1), f(\"b\"): f(2), f(\"c\"): f(3)}; log;",
+			ArrayTest{
+				StringTest("a"),
+				IntegerTest(1),
+				StringTest("b"),
+				IntegerTest(2),
+				StringTest("c"),
+				IntegerTest(3),
+			},
+		},
+		{
+			"quote(unquote({\"b\": 1, \"a\": 2}));",
+			QuoteTest{
+				"{\"b\":1, \"a\":2}",
+			},
+		},
+		{
+			"let a = [1]; a[0] = a; quote(unquote(a));",
+			ErrorTest{
+				"cannot unquote cyclic value: ARRAY",
+			},
+		},
+		{
+			"let h = {}; h[\"h\"] = h; quote(unquote(h));",
+			ErrorTest{
+				"cannot unquote cyclic value: HASH",
+			},
+		},
+		{
+			"let a = [1]; quote(unquote([a, a]));",
+			QuoteTest{
+				"[[1], [1]]",
+			},
+		},
+		{
+			"try { let a = [1]; a[0] = a; quote(unquote(a)); } catch (e) { e[\"message\"] }",
+			StringTest("cannot unquote cyclic value: ARRAY"),
+		},
+		{
+			"chars(1, 2);",
+			ErrorTest{
+				"invalid argument count in call to `chars`: found (INTEGER, INTEGER) want (STRING)",
+			},
+		},
+		{
+			"throw \"boom\";",
+			ErrorTest{
+				"boom",
+			},
+		},
+		{
+			"throw {\"message\": \"boom\", \"code\": 3};",
+			ErrorTest{
+				"boom",
+			},
+		},
+		{
+			"throw [1, 2];",
+			ErrorTest{
+				"[1, 2]",
+			},
+		},
+		{
+			"throw x;",
+			ErrorTest{
+				"undefined reference: x",
+			},
+		},
+		{
+			"try { 1 } catch (e) { 2 };",
+			IntegerTest(1),
+		},
+		{
+			"try { len(1) } catch (e) { e[\"message\"] };",
+			StringTest("invalid argument types in call to `len`: found (INTEGER) want (STRING) or (ARRAY)"),
+		},
+		{
+			"try { len(1) } catch (e) { e[\"value\"] };",
+			NullTest{},
+		},
+		{
+			"try {\n  throw \"boom\";\n} catch (e) { [e[\"message\"], e[\"line\"], e[\"column\"]] };",
+			ArrayTest{
+				StringTest("boom"),
+				IntegerTest(2),
+				IntegerTest(3),
+			},
+		},
+		{
+			"try { throw {\"code\": 3}; } catch (e) { e[\"value\"][\"code\"] };",
+			IntegerTest(3),
+		},
+		{
+			"let f = fn(n) { if (n == 0) { throw n; } f(n - 1) + 1 }; try { f(3) } catch (e) { e[\"value\"] };",
+			IntegerTest(0),
+		},
+		{
+			"let f = fn() { try { return g(); } catch (e) { \"caught\" } }; let g = fn() { throw \"boom\"; }; f();",
+			StringTest("caught"),
+		},
+		{
+			"let f = fn(n) { 1 + f(n + 1) }; try { f(0) } catch (e) { e[\"message\"] };",
+			StringTest("maximum recursion depth exceeded (10000): f"),
+		},
+		{
+			"try { throw \"a\"; } catch (e) { throw \"b\"; };",
+			ErrorTest{
+				"b",
+			},
+		},
+		{
+			"try { try { throw \"a\"; } catch (e) { throw e[\"message\"] + \"b\"; } } catch (e) { e[\"message\"] };",
+			StringTest("ab"),
+		},
+		{
+			"let log = []; try { log = log + [1]; } finally { log = log + [2]; }; log;",
+			ArrayTest{
+				IntegerTest(1),
+				IntegerTest(2),
+			},
+		},
+		{
+			"let log = []; try { throw \"a\"; } catch (e) { log = log + [e[\"message\"]]; } finally { log = log + [\"finally\"]; }; log;",
+			ArrayTest{
+				StringTest("a"),
+				StringTest("finally"),
+			},
+		},
+		{
+			"let log = []; try { try { throw \"a\"; } finally { log = log + [\"finally\"]; } } catch (e) { log = log + [e[\"message\"]]; }; log;",
+			ArrayTest{
+				StringTest("finally"),
+				StringTest("a"),
+			},
+		},
+		{
+			"let log = []; let f = fn() { try { return 1; } finally { log = log + [\"finally\"]; } }; [f(), log];",
+			ArrayTest{
+				IntegerTest(1),
+				ArrayTest{
+					StringTest("finally"),
+				},
+			},
+		},
+		{
+			"let log = []; let g = fn() { log = log + [\"g\"]; 1 }; let f = fn() { try { return g(); } finally { log = log + [\"finally\"]; } }; [f(), log];",
+			ArrayTest{
+				IntegerTest(1),
+				ArrayTest{
+					StringTest("g"),
+					StringTest("finally"),
+				},
+			},
+		},
+		{
+			"let f = fn() { try { return 1; } finally { return 2; } }; f();",
+			IntegerTest(2),
+		},
+		{
+			"try { throw \"a\"; } finally { throw \"b\"; };",
+			ErrorTest{
+				"b",
+			},
+		},
+		{
+			"let i = 0; while (true) { try { i += 1; if (i > 10) { break; } } finally { i += 10; } }; i;",
+			IntegerTest(22),
+		},
+	}
+
+	for i, test := range tests {
+		l := lexer.NewLexer(test.input)
+		p := parser.NewParser(l)
+		program := p.ParseProgram()
+		env := object.NewEnvironment()
+		eval := Eval(program, env)
+
+		if !testObject(t, i, test.input, eval, test.test) {
+			continue
+		}
+	}
+}
+
+func TestEvalStrict(t *testing.T) {
+	tests := []struct {
+		input string
+		test  ObjectTest
+	}{
+		{
+			"[1, 2, 3][-1];",
+			IntegerTest(3),
+		},
+		{
+			"[1, 2, 3][3];",
+			ErrorTest{
+				"index out of range: ARRAY[3] with length 3",
+			},
+		},
+		{
+			"let f = fn(a) { a[-4] }; f([1, 2, 3]);",
+			ErrorTest{
+				"index out of range: ARRAY[-4] with length 3",
+			},
+		},
+		{
+			"\"héllo\"[5];",
+			ErrorTest{
+				"index out of range: STRING[5] with length 5",
+			},
+		},
+		{
+			"{\"a\": 1}[\"a\"];",
+			IntegerTest(1),
+		},
+		{
+			"{\"a\": 1}[\"b\"];",
+			ErrorTest{
+				"key not found: HASH[\"b\"]",
+			},
+		},
+		{
+			"let h = {}; h[1] += 1;",
+			ErrorTest{
+				"key not found: HASH[1]",
+			},
+		},
+	}
+
+	for i, test := range tests {
+		l := lexer.NewLexer(test.input)
+		p := parser.NewParser(l)
+		program := p.ParseProgram()
+		env := object.NewEnvironment()
+		env.SetStrict(true)
+		eval := Eval(program, env)
+
+		if !testObject(t, i, test.input, eval, test.test) {
+			continue
+		}
+	}
+}
+
+func TestEvalTailCalls(t *testing.T) {
+	// Without tail calls each level of recursion below costs several Go stack
+	// frames, so a depth of 100000 overflows this limit.
+	defer debug.SetMaxStack(debug.SetMaxStack(8 << 20))
+
+	tests := []struct {
+		input string
+		test  ObjectTest
+	}{
+		{
+			"let count = fn(n, acc) { if (n == 0) { acc } else { count(n - 1, acc + 1) } }; count(100000, 0);",
+			IntegerTest(100000),
+		},
+		{
+			"let count = fn(n, acc) { if (n == 0) { return acc; } return count(n - 1, acc + 1); }; count(100000, 0);",
+			IntegerTest(100000),
+		},
+		{
+			"let count = fn(n) { while (true) { if (n == 0) { return \"done\"; } return count(n - 1); } }; count(100000);",
+			StringTest("done"),
+		},
+		{
+			"let count = fn(n) { return if (n == 0) { \"done\" } else { count(n - 1) }; }; count(100000);",
+			StringTest("done"),
+		},
+		{
+			"let even? = fn(n) { if (n == 0) { true } else { odd?(n - 1) } }; let odd? = fn(n) { if (n == 0) { false } else { even?(n - 1) } }; even?(100001);",
+			BooleanTest(false),
+		},
+		{
+			"let count = fn(n, ...rest) { if (n == 0) { len(rest) } else { count(n - 1, n) } }; count(100000);",
+			IntegerTest(1),
+		},
+		{
+			"let count = fn(n) { if (n == 0) { missing } else { count(n - 1) } }; count(100000);",
+			ErrorTest{
+				"undefined reference: missing",
+			},
+		},
+		{
+			"let f = fn(n) { n + 1 }; let g = fn(n) { f(n) * 2 }; g(1);",
+			IntegerTest(4),
+		},
+	}
+
+	for i, test := range tests {
+		l := lexer.NewLexer(test.input)
+		p := parser.NewParser(l)
+		program := p.ParseProgram()
+		env := object.NewEnvironment()
+		eval := Eval(program, env)
+
+		if !testObject(t, i, test.input, eval, test.test) {
+			continue
+		}
+	}
+}
+
+func TestEvalRecursionDepth(t *testing.T) {
+	tests := []struct {
+		input    string
+		maxDepth int
+		test     ObjectTest
+	}{
+		{
+			"let sum = fn(n) { if (n == 0) { 0 } else { n + sum(n - 1) } }; sum(1000000);",
+			object.DEFAULT_MAX_DEPTH,
+			ErrorTest{
+				"maximum recursion depth exceeded (10000): sum",
+			},
+		},
+		{
+			"let sum = fn(n) { if (n == 0) { 0 } else { n + sum(n - 1) } }; sum(99);",
+			100,
+			IntegerTest(4950),
+		},
+		{
+			"let sum = fn(n) { if (n == 0) { 0 } else { n + sum(n - 1) } }; sum(100);",
+			100,
+			ErrorTest{
+				"maximum recursion depth exceeded (100): sum",
+			},
+		},
+		{
+			"let sum = fn(n) { if (n == 0) { 0 } else { n + sum(n - 1) } }; let main = fn() { sum(200) + 0 }; main();",
+			100,
+			ErrorTest{
+				"maximum recursion depth exceeded (100): main -> sum",
+			},
+		},
+		{
+			"let even? = fn(n) { if (n == 0) { true } else { !odd?(n - 1) } }; let odd? = fn(n) { if (n == 0) { false } else { !even?(n - 1) } }; even?(200);",
+			100,
+			ErrorTest{
+				"maximum recursion depth exceeded (100): even? -> odd?",
+			},
+		},
+		{
+			"fn(f) { f(f) + 1 }(fn(f) { f(f) + 1 });",
+			100,
+			ErrorTest{
+				"maximum recursion depth exceeded (100): <anonymous>",
+			},
+		},
+		{
+			"let count = fn(n) { if (n == 0) { 0 } else { count(n - 1) } }; count(1000);",
+			100,
+			IntegerTest(0),
+		},
+	}
+
+	for i, test := range tests {
+		l := lexer.NewLexer(test.input)
+		p := parser.NewParser(l)
+		program := p.ParseProgram()
+		env := object.NewEnvironment()
+		env.SetMaxDepth(test.maxDepth)
+		eval := Eval(program, env)
+
+		if !testObject(t, i, test.input, eval, test.test) {
+			continue
+		}
+
+		if len(env.Calls()) != 0 {
+			t.Errorf("test[%d] - %q - len(env.Calls()) ==> expected: 0 actual: %d", i, test.input, len(env.Calls()))
+		}
+	}
+}
+
+func TestEvalErrorPositions(t *testing.T) {
+	tests := []struct {
+		input   string
+		message string
+		line    int
+		column  int
+		stack   []object.Frame
+	}{
+		{
+			"let x = 1;\nx + y;",
+			"undefined reference: y",
+			2,
+			5,
+			[]object.Frame{},
+		},
+		{
+			"1 +\n  true;",
+			"unknown operation: INTEGER + BOOLEAN",
+			1,
+			3,
+			[]object.Frame{},
+		},
+		{
+			"len(1, 2);",
+			"invalid argument count in call to `len`: found (INTEGER, INTEGER) want (STRING) or (ARRAY)",
+			1,
+			4,
+			[]object.Frame{},
+		},
+		{
+			"let f = fn(x) { x; };\nf();",
+			"invalid argument count in call to `f`: found 0 want 1",
+			2,
+			2,
+			[]object.Frame{},
+		},
+		{
+			"let f = fn(x) {\n  x + y\n};\nlet g = fn() {\n  let r = f(1);\n  r\n};\ng();",
+			"undefined reference: y",
+			2,
+			7,
+			[]object.Frame{
+				{Function: "f", Line: 5, Column: 12},
+				{Function: "g", Line: 8, Column: 2},
+			},
+		},
+		{
+			"let f = fn(x = y) { x };\nf();",
+			"undefined reference: y",
+			1,
+			16,
+			[]object.Frame{
+				{Function: "f", Line: 2, Column: 2},
+			},
+		},
+		{
+			"let f = fn() { [][\"a\"] };\nlet g = fn() { f() };\nlet h = fn() { g() + 1 };\nh();",
+			"unknown operation: ARRAY[STRING]",
+			1,
+			18,
+			[]object.Frame{
+				{Function: "f", Line: 3, Column: 17},
+				{Function: "h", Line: 4, Column: 2},
+			},
+		},
+		{
+			"let f = fn() { g(1) };\nlet g = fn() { 1 };\nf();",
+			"invalid argument count in call to `g`: found 1 want 0",
+			1,
+			17,
+			[]object.Frame{
+				{Function: "f", Line: 3, Column: 2},
+			},
+		},
+		{
+			"let f = fn(n) { if (n == 0) { -true } else { 1 + f(n - 1) } };\nf(2);",
+			"unknown operation: -BOOLEAN",
+			1,
+			31,
+			[]object.Frame{
+				{Function: "f", Line: 1, Column: 51},
+				{Function: "f", Line: 1, Column: 51},
+				{Function: "f", Line: 2, Column: 2},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		l := lexer.NewLexer(test.input)
+		p := parser.NewParser(l)
+		program := p.ParseProgram()
+		env := object.NewEnvironment()
+		eval := Eval(program, env)
+
+		err, ok := eval.(*object.Error)
+		if !ok {
+			t.Errorf("test[%d] - %q - eval ==> unexpected type. expected: %T actual: %T(%+v)", i, test.input, &object.Error{}, eval, eval)
+			continue
+		}
+
+		if test.message != err.Message {
+			t.Errorf("test[%d] - %q - err.Message ==> expected: %q actual: %q", i, test.input, test.message, err.Message)
+			continue
+		}
+
+		if test.line != err.Line || test.column != err.Column {
+			t.Errorf("test[%d] - %q - err position ==> expected: %d:%d actual: %d:%d", i, test.input, test.line, test.column, err.Line, err.Column)
+			continue
+		}
+
+		if len(test.stack) != len(err.Stack) {
+			t.Errorf("test[%d] - %q - len(err.Stack) ==> expected: %d actual: %d (%+v)", i, test.input, len(test.stack), len(err.Stack), err.Stack)
+			continue
+		}
+
+		for j, frame := range err.Stack {
+			if test.stack[j] != frame {
+				t.Errorf("test[%d] - %q - err.Stack[%d] ==> expected: %+v actual: %+v", i, test.input, j, test.stack[j], frame)
+			}
+		}
+	}
+}
+
+func TestEvalTruthiness(t *testing.T) {
+	tests := []struct {
+		value    object.Object
+		expected bool
+	}{
+		{&object.Integer{Value: 0}, false},
+		{&object.Float{Value: 0}, false},
+		{&object.String{Value: ""}, false},
+		{&object.Array{Elements: []object.Object{}}, false},
+		{&object.Hash{Pairs: []object.HashPair{}}, false},
+		{&object.Boolean{Value: false}, false},
+		{&object.Null{}, false},
+		{&object.Integer{Value: 2}, true},
+		{&object.String{Value: "a"}, true},
+		{&object.Array{Elements: []object.Object{&object.Null{}}}, true},
+		{&object.Boolean{Value: true}, true},
+	}
+
+	inputs := []string{
+		"if (value) { true } else { false };",
+		"!!value;",
+		"let r = false; while (value) { r = true; break; }; r;",
+		"(value && true) || false;",
+	}
+
+	for i, test := range tests {
+		for _, input := range inputs {
+			l := lexer.NewLexer(input)
+			p := parser.NewParser(l)
+			program := p.ParseProgram()
+			env := object.NewEnvironment()
+			env.Set("value", test.value)
+			eval := Eval(program, env)
+
+			if !testBoolean(t, i, input, eval, test.expected) {
+				continue
+			}
+		}
+	}
+}
+
+func TestEvalBooleanEquality(t *testing.T) {
+	tests := []struct {
+		input    string
+		value    object.Object
+		expected bool
+	}{
+		{"value == true;", &object.Boolean{Value: true}, true},
+		{"true == value;", &object.Boolean{Value: true}, true},
+		{"value != true;", &object.Boolean{Value: true}, false},
+		{"value == false;", &object.Boolean{Value: false}, true},
+		{"value != false;", &object.Boolean{Value: true}, true},
+		{"value == false;", &object.Boolean{Value: true}, false},
+	}
+
+	for i, test := range tests {
+		l := lexer.NewLexer(test.input)
+		p := parser.NewParser(l)
+		program := p.ParseProgram()
+		env := object.NewEnvironment()
+		env.Set("value", test.value)
+		eval := Eval(program, env)
+
+		testBoolean(t, i, test.input, eval, test.expected)
+	}
+}
+
+func testObject(t *testing.T, idx int, input string, obj object.Object, test ObjectTest) bool {
+	switch test := test.(type) {
+	case IntegerTest:
+		return testInteger(t, idx, input, obj, int64(test))
+	case BigIntegerTest:
+		return testBigInteger(t, idx, input, obj, string(test))
+	case FloatTest:
+		return testFloat(t, idx, input, obj, float64(test))
+	case BooleanTest:
+		return testBoolean(t, idx, input, obj, bool(test))
+	case NullTest:
+		return testNull(t, idx, input, obj)
+	case ErrorTest:
+		return testError(t, idx, input, obj, test.message)
+	case FunctionTest:
+		return testFunction(t, idx, input, obj, test.parameters, test.body)
+	case StringTest:
+		return testString(t, idx, input, obj, string(test))
+	case ArrayTest:
+		return testArray(t, idx, input, obj, []ObjectTest(test))
+	case HashTest:
+		return testHash(t, idx, input, obj, map[object.HashKey]ObjectTest(test))
+	case QuoteTest:
+		return testQuote(t, idx, input, obj, test.node)
+	}
+	t.Errorf("test[%d] - %q ==> unexpected type. actual: %T", idx, input, test)
+	return false
+}
+
+func testInteger(t *testing.T, idx int, input string, obj object.Object, value int64) bool {
+	result, ok := obj.(*object.Integer)
+	if !ok {
+		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Integer{}, obj)
+		return false
+	}
+
+	if value != result.Value {
+		t.Errorf("test[%d] - %q - result.Value ==> expected: %d actual: %d", idx, input, value, result.Value)
+		return false
+	}
+
+	return true
+}
+
+func testBigInteger(t *testing.T, idx int, input string, obj object.Object, value string) bool {
+	result, ok := obj.(*object.BigInteger)
+	if !ok {
+		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.BigInteger{}, obj)
+		return false
+	}
+
+	if value != result.Value.String() {
+		t.Errorf("test[%d] - %q - result.Value ==> expected: %s actual: %s", idx, input, value, result.Value.String())
+		return false
+	}
+
+	return true
+}
+
+func testFloat(t *testing.T, idx int, input string, obj object.Object, value float64) bool {
+	result, ok := obj.(*object.Float)
+	if !ok {
+		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Float{}, obj)
+		return false
+	}
+
+	if value != result.Value {
+		t.Errorf("test[%d] - %q - result.Value ==> expected: %g actual: %g", idx, input, value, result.Value)
+		return false
+	}
+
+	return true
+}
+
+func testBoolean(t *testing.T, idx int, input string, obj object.Object, value bool) bool {
+	result, ok := obj.(*object.Boolean)
+	if !ok {
+		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Boolean{}, obj)
+		return false
+	}
+
+	if value != result.Value {
+		t.Errorf("test[%d] - %q - result.Value ==> expected: %t actual: %t", idx, input, value, result.Value)
+		return false
+	}
+
+	return true
+}
+
+func testNull(t *testing.T, idx int, input string, obj object.Object) bool {
+	if NULL != obj {
+		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Null{}, obj)
+		return false
+	}
+
+	return true
+}
+
+func testError(t *testing.T, idx int, input string, obj object.Object, message string) bool {
+	result, ok := obj.(*object.Error)
+	if !ok {
+		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Error{}, obj)
+		return false
+	}
+
+	if message != result.Message {
+		t.Errorf("test[%d] - %q - result.Message ==> expected: %q actual: %q", idx, input, message, result.Message)
+		return false
+	}
+
+	return true
+}
+
+func testFunction(t *testing.T, idx int, input string, obj object.Object, params []string, body string) bool {
+	result, ok := obj.(*object.Function)
+	if !ok {
+		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Function{}, obj)
+		return false
+	}
+
+	if len(params) != len(result.Parameters) {
+		t.Errorf("test[%d] - %q - len(result.Parameters) ==> expected: %d actual: %d", idx, input, len(params), len(result.Parameters))
+		return false
+	}
+
+	for i, param := range result.Parameters {
+		if params[i] != param.String() {
+			t.Errorf("test[%d] - %q - result.Parameters[%d].String() ==> expected: %q actual: %q", idx, input, i, params[i], param)
+			return false
+		}
+	}
+
+	actual := result.Body.String()
+	if body != actual {
+		t.Errorf("test[%d] - %q - result.Body.String() ==> expected: %q actual: %q", idx, input, body, actual)
+		return false
+	}
+
+	return true
+}
+
+func testString(t *testing.T, idx int, input string, obj object.Object, value string) bool {
+	result, ok := obj.(*object.String)
+	if !ok {
+		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.String{}, obj)
+		return false
+	}
+
+	if value != result.Value {
+		t.Errorf("test[%d] - %q - result.Value ==> expected: %q actual: %q", idx, input, value, result.Value)
+		return false
+	}
+
+	return true
+}
+
+func testArray(t *testing.T, idx int, input string, obj object.Object, tests []ObjectTest) bool {
+	result, ok := obj.(*object.Array)
+	if !ok {
+		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Array{}, obj)
+		return false
+	}
+
+	if len(tests) != len(result.Elements) {
+		t.Errorf("test[%d] - %q - len(result.Elements) ==> expected: %d actual: %d", idx, input, len(tests), len(result.Elements))
+		return false
+	}
+
+	for i, elem := range result.Elements {
+		if !testObject(t, idx, input, elem, tests[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func testHash(t *testing.T, idx int, input string, obj object.Object, tests map[object.HashKey]ObjectTest) bool {
+	result, ok := obj.(*object.Hash)
+	if !ok {
+		t.Errorf("test[%d] - %q - obj ==> unexpected type. expected: %T actual: %T", idx, input, object.Hash{}, obj)
+		return false
+	}
+
+	if len(tests) != len(result.Pairs) {
+		t.Errorf("test[%d] - %q - len(result.Pairs) ==> expected: %d actual: %d", idx, input, len(tests), len(result.Pairs))
+		return false
+	}
+
+	for _, value := range result.Pairs {
+		key, ok := value.Key.(object.Hashable)
+		if !ok {
+			t.Errorf("test[%d] - %q - %T(%+v) ==> expected: hashable", idx, input, value.Key, value.Key)
+			return false
+		}
 
-	for key, value := range result.Pairs {
-		test, ok := tests[key]
+		test, ok := tests[key.HashKey()]
 		if !ok {
 			t.Errorf("test[%d] - %q - %T(%+v) ==> expected: not <nil>", idx, input, value.Key, value.Key)
 			return false
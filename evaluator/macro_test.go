@@ -68,7 +68,7 @@ func TestExpandMacros(t *testing.T) {
 		},
 		{
 			"let unless = macro(condition, consequence, alternative) { quote(if (!(unquote(condition))) { unquote(consequence); } else { unquote(alternative); }); }; unless(10 > 5, puts(\"false\"), puts(\"true\"));",
-			"if (!(10 > 5)) puts(false) else puts(true)",
+			"if (!(10 > 5)) puts(\"false\") else puts(\"true\")",
 		},
 	}
 
@@ -1,6 +1,14 @@
 package lexer
 
-import "github.com/eugene-whitaker/writing-an-interpreter-in-go/token"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/eugene-whitaker/writing-an-interpreter-in-go/token"
+)
 
 type Lexer struct {
 	input string
@@ -8,6 +16,18 @@ type Lexer struct {
 
 	start   int
 	current int
+
+	errors map[int]string
+
+	// Brace depth of each open ${...} interpolation, innermost last.
+	interpolations []int
+
+	comments []token.Token
+
+	// Line and column of offset, the last offset converted by position.
+	offset int
+	line   int
+	column int
 }
 
 func NewLexer(input string) *Lexer {
@@ -16,10 +36,20 @@ func NewLexer(input string) *Lexer {
 		ch:      0,
 		start:   0,
 		current: 0,
+		errors:  map[int]string{},
+		offset:  0,
+		line:    1,
+		column:  1,
 	}
 }
 
 func (l *Lexer) NextToken() token.Token {
+	tok := l.nextToken()
+	tok.Line, tok.Column = l.position(tok.Offset)
+	return tok
+}
+
+func (l *Lexer) nextToken() token.Token {
 	l.advance()
 
 	for l.ch != 0 {
@@ -36,9 +66,27 @@ func (l *Lexer) NextToken() token.Token {
 				return l.emit(token.ASSIGN)
 			}
 		case '+':
-			return l.emit(token.PLUS)
+			if l.match('=') {
+				return token.Token{
+					Type:   token.PLUS_ASSIGN,
+					Lexeme: l.input[l.start:l.current],
+					Offset: l.start,
+					Length: l.current - l.start,
+				}
+			} else {
+				return l.emit(token.PLUS)
+			}
 		case '-':
-			return l.emit(token.MINUS)
+			if l.match('=') {
+				return token.Token{
+					Type:   token.MINUS_ASSIGN,
+					Lexeme: l.input[l.start:l.current],
+					Offset: l.start,
+					Length: l.current - l.start,
+				}
+			} else {
+				return l.emit(token.MINUS)
+			}
 		case '!':
 			if l.match('=') {
 				return token.Token{
@@ -51,36 +99,140 @@ func (l *Lexer) NextToken() token.Token {
 				return l.emit(token.BANG)
 			}
 		case '/':
-			return l.emit(token.SLASH)
+			if l.match('=') {
+				return token.Token{
+					Type:   token.SLASH_ASSIGN,
+					Lexeme: l.input[l.start:l.current],
+					Offset: l.start,
+					Length: l.current - l.start,
+				}
+			} else if l.match('/') {
+				l.lineComment()
+			} else if l.match('*') {
+				if !l.blockComment() {
+					l.errors[l.start] = "unterminated block comment"
+					return token.Token{
+						Type:   token.ILLEGAL,
+						Lexeme: l.input[l.start:l.current],
+						Offset: l.start,
+						Length: l.current - l.start,
+					}
+				}
+			} else {
+				return l.emit(token.SLASH)
+			}
 		case '*':
-			return l.emit(token.ASTERISK)
+			if l.match('*') {
+				return token.Token{
+					Type:   token.POWER,
+					Lexeme: l.input[l.start:l.current],
+					Offset: l.start,
+					Length: l.current - l.start,
+				}
+			} else if l.match('=') {
+				return token.Token{
+					Type:   token.ASTERISK_ASSIGN,
+					Lexeme: l.input[l.start:l.current],
+					Offset: l.start,
+					Length: l.current - l.start,
+				}
+			} else {
+				return l.emit(token.ASTERISK)
+			}
+		case '&':
+			if l.match('&') {
+				return token.Token{
+					Type:   token.AND,
+					Lexeme: l.input[l.start:l.current],
+					Offset: l.start,
+					Length: l.current - l.start,
+				}
+			} else {
+				return l.emit(token.ILLEGAL)
+			}
+		case '|':
+			if l.match('|') {
+				return token.Token{
+					Type:   token.OR,
+					Lexeme: l.input[l.start:l.current],
+					Offset: l.start,
+					Length: l.current - l.start,
+				}
+			} else {
+				return l.emit(token.ILLEGAL)
+			}
+		case '%':
+			return l.emit(token.PERCENT)
 		case '<':
-			return l.emit(token.LT)
+			if l.match('=') {
+				return token.Token{
+					Type:   token.LT_EQ,
+					Lexeme: l.input[l.start:l.current],
+					Offset: l.start,
+					Length: l.current - l.start,
+				}
+			} else {
+				return l.emit(token.LT)
+			}
 		case '>':
-			return l.emit(token.GT)
+			if l.match('=') {
+				return token.Token{
+					Type:   token.GT_EQ,
+					Lexeme: l.input[l.start:l.current],
+					Offset: l.start,
+					Length: l.current - l.start,
+				}
+			} else {
+				return l.emit(token.GT)
+			}
 		case ':':
 			return l.emit(token.COLON)
 		case ';':
 			return l.emit(token.SEMICOLON)
 		case ',':
 			return l.emit(token.COMMA)
+		case '.':
+			if l.peek() == '.' && l.lookahead(1) == '.' {
+				l.skip(2)
+				return token.Token{
+					Type:   token.ELLIPSIS,
+					Lexeme: l.input[l.start:l.current],
+					Offset: l.start,
+					Length: l.current - l.start,
+				}
+			} else {
+				return l.emit(token.ILLEGAL)
+			}
 		case '(':
 			return l.emit(token.LPAREN)
 		case ')':
 			return l.emit(token.RPAREN)
 		case '{':
+			if n := len(l.interpolations); n > 0 {
+				l.interpolations[n-1] = l.interpolations[n-1] + 1
+			}
 			return l.emit(token.LBRACE)
 		case '}':
+			if n := len(l.interpolations); n > 0 {
+				if l.interpolations[n-1] == 0 {
+					l.interpolations = l.interpolations[:n-1]
+					return l.string(true)
+				}
+				l.interpolations[n-1] = l.interpolations[n-1] - 1
+			}
 			return l.emit(token.RBRACE)
 		case '[':
 			return l.emit(token.LBRACKET)
 		case ']':
 			return l.emit(token.RBRACKET)
 		case '"':
-			return l.string()
+			return l.string(false)
+		case '`':
+			return l.rawString()
 		case ' ', '\t', '\r', '\n':
 		default:
-			if isLetter(l.ch) {
+			if r, size := utf8.DecodeRuneInString(l.input[l.start:]); isLetter(r) {
+				l.skip(size - 1)
 				literal := l.identifier()
 				return token.Token{
 					Type:   token.LookupKeyword(literal),
@@ -89,15 +241,15 @@ func (l *Lexer) NextToken() token.Token {
 					Length: l.current - l.start,
 				}
 			} else if isDigit(l.ch) {
-				literal := l.number()
+				return l.number()
+			} else {
+				l.skip(size - 1)
 				return token.Token{
-					Type:   token.INT,
-					Lexeme: literal,
+					Type:   token.ILLEGAL,
+					Lexeme: l.input[l.start:l.current],
 					Offset: l.start,
 					Length: l.current - l.start,
 				}
-			} else {
-				return l.emit(token.ILLEGAL)
 			}
 		}
 
@@ -116,6 +268,19 @@ func (l *Lexer) Input() string {
 	return l.input
 }
 
+// Comments returns the comments skipped so far, in source order, for tooling
+// that needs to preserve them.
+func (l *Lexer) Comments() []token.Token {
+	return l.comments
+}
+
+// Error reports why the ILLEGAL token at offset was emitted, if the lexer
+// recorded a reason for it.
+func (l *Lexer) Error(offset int) (string, bool) {
+	msg, ok := l.errors[offset]
+	return msg, ok
+}
+
 func (l *Lexer) advance() {
 	l.start = l.current
 	if l.current >= len(l.input) {
@@ -142,6 +307,13 @@ func (l *Lexer) peek() byte {
 	return l.input[l.current]
 }
 
+func (l *Lexer) lookahead(n int) byte {
+	if l.current+n >= len(l.input) {
+		return 0
+	}
+	return l.input[l.current+n]
+}
+
 func (l *Lexer) match(expected byte) bool {
 	if l.current >= len(l.input) {
 		return false
@@ -155,31 +327,317 @@ func (l *Lexer) match(expected byte) bool {
 	return true
 }
 
+// skip consumes the next n bytes, used to step over the rest of a rune.
+func (l *Lexer) skip(n int) {
+	for i := 0; i < n; i = i + 1 {
+		l.consume()
+	}
+}
+
+// identifier scans the rest of an identifier whose first rune has already
+// been consumed. A single trailing ? or ! is allowed for predicate names, as
+// long as the ! does not start a != operator.
 func (l *Lexer) identifier() string {
-	for isLetter(l.peek()) {
+	for {
+		r, size := utf8.DecodeRuneInString(l.input[l.current:])
+		if !isLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		l.skip(size)
+	}
+
+	if l.peek() == '?' || l.peek() == '!' && l.lookahead(1) != '=' {
 		l.consume()
 	}
+
 	return l.input[l.start:l.current]
 }
 
-func (l *Lexer) number() string {
-	for isDigit(l.peek()) {
+func (l *Lexer) lineComment() {
+	for l.peek() != '\n' && l.peek() != 0 {
 		l.consume()
 	}
-	return l.input[l.start:l.current]
+
+	l.comment()
 }
 
-func (l *Lexer) string() token.Token {
+// blockComment skips a possibly nested /* */ comment, reporting false when
+// the input ends before it is closed.
+func (l *Lexer) blockComment() bool {
+	depth := 1
+
+	for depth > 0 {
+		switch {
+		case l.peek() == 0:
+			return false
+		case l.peek() == '/' && l.lookahead(1) == '*':
+			l.consume()
+			l.consume()
+			depth = depth + 1
+		case l.peek() == '*' && l.lookahead(1) == '/':
+			l.consume()
+			l.consume()
+			depth = depth - 1
+		default:
+			l.consume()
+		}
+	}
+
+	l.comment()
+	return true
+}
+
+func (l *Lexer) comment() {
+	line, column := l.position(l.start)
+	l.comments = append(l.comments, token.Token{
+		Type:   token.COMMENT,
+		Lexeme: l.input[l.start:l.current],
+		Offset: l.start,
+		Length: l.current - l.start,
+		Line:   line,
+		Column: column,
+	})
+}
+
+// position converts an offset into a line and column, counting on from the last call.
+func (l *Lexer) position(offset int) (int, int) {
+	if offset < l.offset {
+		l.offset = 0
+		l.line = 1
+		l.column = 1
+	}
+
+	for ; l.offset < offset; l.offset = l.offset + 1 {
+		if l.input[l.offset] == '\n' {
+			l.line = l.line + 1
+			l.column = 1
+		} else {
+			l.column = l.column + 1
+		}
+	}
+
+	return l.line, l.column
+}
+
+// number scans an integer or float literal. Digits may be separated by _,
+// and integers may carry a 0x, 0o or 0b prefix; the parser validates the
+// digits, so a prefixed literal swallows any trailing letters and digits
+// to be reported as a single malformed token.
+func (l *Lexer) number() token.Token {
+	var ttype token.TokenType = token.INT
+
+	if l.ch == '0' && isBasePrefix(l.peek()) {
+		l.consume()
+		for isAlphanumeric(l.peek()) {
+			l.consume()
+		}
+
+		return token.Token{
+			Type:   ttype,
+			Lexeme: l.input[l.start:l.current],
+			Offset: l.start,
+			Length: l.current - l.start,
+		}
+	}
+
+	for isDigit(l.peek()) || l.peek() == '_' {
+		l.consume()
+	}
+
+	if l.peek() == '.' && isDigit(l.lookahead(1)) {
+		ttype = token.FLOAT
+
+		l.consume()
+		for isDigit(l.peek()) || l.peek() == '_' {
+			l.consume()
+		}
+	}
+
+	if l.peek() == 'e' || l.peek() == 'E' {
+		n := 1
+		if l.lookahead(1) == '+' || l.lookahead(1) == '-' {
+			n = 2
+		}
+
+		if isDigit(l.lookahead(n)) {
+			ttype = token.FLOAT
+
+			for i := 0; i < n; i = i + 1 {
+				l.consume()
+			}
+			for isDigit(l.peek()) || l.peek() == '_' {
+				l.consume()
+			}
+		} else {
+			for i := 0; i < n; i = i + 1 {
+				l.consume()
+			}
+
+			l.errors[l.start] = "malformed exponent"
+			return token.Token{
+				Type:   token.ILLEGAL,
+				Lexeme: l.input[l.start:l.current],
+				Offset: l.start,
+				Length: l.current - l.start,
+			}
+		}
+	}
+
+	return token.Token{
+		Type:   ttype,
+		Lexeme: l.input[l.start:l.current],
+		Offset: l.start,
+		Length: l.current - l.start,
+	}
+}
+
+// string scans a string literal up to its closing quote or the next ${,
+// resuming after the } of an interpolation when resumed is set.
+func (l *Lexer) string(resumed bool) token.Token {
+	var out strings.Builder
+	var illegal *token.Token
+
 	for l.peek() != '"' && l.peek() != 0 {
 		l.consume()
+
+		if l.ch == '$' && l.peek() == '{' {
+			l.consume()
+			l.interpolations = append(l.interpolations, 0)
+
+			if illegal != nil {
+				return *illegal
+			}
+
+			var ttype token.TokenType = token.STRING_HEAD
+			if resumed {
+				ttype = token.STRING_MIDDLE
+			}
+
+			return token.Token{
+				Type:   ttype,
+				Lexeme: out.String(),
+				Offset: l.start,
+				Length: l.current - l.start,
+			}
+		}
+
+		if l.ch != '\\' {
+			out.WriteByte(l.ch)
+			continue
+		}
+
+		offset := l.current - 1
+		value, ok := l.escape()
+		if !ok {
+			if illegal == nil {
+				l.errors[offset] = fmt.Sprintf("invalid escape sequence <%s> in string", l.input[offset:l.current])
+				illegal = &token.Token{
+					Type:   token.ILLEGAL,
+					Lexeme: l.input[offset:l.current],
+					Offset: offset,
+					Length: l.current - offset,
+				}
+			}
+			continue
+		}
+		out.WriteRune(value)
 	}
 
 	if l.peek() != '"' {
+		l.errors[l.start] = "unterminated string"
 		return token.Token{
 			Type:   token.ILLEGAL,
 			Lexeme: l.input[l.start:l.current],
 			Offset: l.start,
-			Length: l.start - l.current,
+			Length: l.current - l.start,
+		}
+	}
+
+	l.consume()
+
+	if illegal != nil {
+		return *illegal
+	}
+
+	var ttype token.TokenType = token.STRING
+	if resumed {
+		ttype = token.STRING_TAIL
+	}
+
+	return token.Token{
+		Type:   ttype,
+		Lexeme: out.String(),
+		Offset: l.start,
+		Length: l.current - l.start,
+	}
+}
+
+// escape decodes the escape sequence following a backslash, reporting false
+// when it is not one of \n, \t, \\, \", \$ or \u{...}.
+func (l *Lexer) escape() (rune, bool) {
+	switch l.peek() {
+	case 'n':
+		l.consume()
+		return '\n', true
+	case 't':
+		l.consume()
+		return '\t', true
+	case '\\':
+		l.consume()
+		return '\\', true
+	case '"':
+		l.consume()
+		return '"', true
+	case '$':
+		l.consume()
+		return '$', true
+	case 'u':
+		l.consume()
+		if l.peek() != '{' {
+			return 0, false
+		}
+		l.consume()
+
+		start := l.current
+		for isHexDigit(l.peek()) {
+			l.consume()
+		}
+		digits := l.input[start:l.current]
+
+		if l.peek() != '}' {
+			return 0, false
+		}
+		l.consume()
+
+		if len(digits) == 0 || len(digits) > 6 {
+			return 0, false
+		}
+
+		value, err := strconv.ParseUint(digits, 16, 32)
+		if err != nil || !utf8.ValidRune(rune(value)) {
+			return 0, false
+		}
+		return rune(value), true
+	case 0:
+		return 0, false
+	default:
+		l.consume()
+		return 0, false
+	}
+}
+
+func (l *Lexer) rawString() token.Token {
+	for l.peek() != '`' && l.peek() != 0 {
+		l.consume()
+	}
+
+	if l.peek() != '`' {
+		l.errors[l.start] = "unterminated raw string"
+		return token.Token{
+			Type:   token.ILLEGAL,
+			Lexeme: l.input[l.start:l.current],
+			Offset: l.start,
+			Length: l.current - l.start,
 		}
 	}
 
@@ -189,7 +647,7 @@ func (l *Lexer) string() token.Token {
 		Type:   token.STRING,
 		Lexeme: l.input[l.start+1 : l.current-1],
 		Offset: l.start,
-		Length: l.start - l.current,
+		Length: l.current - l.start,
 	}
 }
 
@@ -202,10 +660,27 @@ func (l *Lexer) emit(ttype token.TokenType) token.Token {
 	}
 }
 
-func isLetter(ch byte) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+func isLetter(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
 }
 
 func isDigit(ch byte) bool {
 	return '0' <= ch && ch <= '9'
 }
+
+func isAlphanumeric(ch byte) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+}
+
+func isBasePrefix(ch byte) bool {
+	switch ch {
+	case 'x', 'X', 'o', 'O', 'b', 'B':
+		return true
+	default:
+		return false
+	}
+}
+
+func isHexDigit(ch byte) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
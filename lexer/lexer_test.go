@@ -60,6 +60,50 @@ func TestNextToken(t *testing.T) {
 				{token.EOF, ""},
 			},
 		},
+		{
+			"fn(x, y = 1, ...rest) {}; a.b",
+			[]TokenTest{
+				{token.FUNCTION, "fn"},
+				{token.LPAREN, "("},
+				{token.IDENT, "x"},
+				{token.COMMA, ","},
+				{token.IDENT, "y"},
+				{token.ASSIGN, "="},
+				{token.INT, "1"},
+				{token.COMMA, ","},
+				{token.ELLIPSIS, "..."},
+				{token.IDENT, "rest"},
+				{token.RPAREN, ")"},
+				{token.LBRACE, "{"},
+				{token.RBRACE, "}"},
+				{token.SEMICOLON, ";"},
+				{token.IDENT, "a"},
+				{token.ILLEGAL, "."},
+				{token.IDENT, "b"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"try { throw e; } catch (e) {} finally {}",
+			[]TokenTest{
+				{token.TRY, "try"},
+				{token.LBRACE, "{"},
+				{token.THROW, "throw"},
+				{token.IDENT, "e"},
+				{token.SEMICOLON, ";"},
+				{token.RBRACE, "}"},
+				{token.CATCH, "catch"},
+				{token.LPAREN, "("},
+				{token.IDENT, "e"},
+				{token.RPAREN, ")"},
+				{token.LBRACE, "{"},
+				{token.RBRACE, "}"},
+				{token.FINALLY, "finally"},
+				{token.LBRACE, "{"},
+				{token.RBRACE, "}"},
+				{token.EOF, ""},
+			},
+		},
 		{
 			"let result = add(five, ten);",
 			[]TokenTest{
@@ -298,6 +342,210 @@ func TestNextToken(t *testing.T) {
 				{token.EOF, ""},
 			},
 		},
+		{
+			"while (x) { break; }",
+			[]TokenTest{
+				{token.WHILE, "while"},
+				{token.LPAREN, "("},
+				{token.IDENT, "x"},
+				{token.RPAREN, ")"},
+				{token.LBRACE, "{"},
+				{token.BREAK, "break"},
+				{token.SEMICOLON, ";"},
+				{token.RBRACE, "}"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"for (;;) { continue; }",
+			[]TokenTest{
+				{token.FOR, "for"},
+				{token.LPAREN, "("},
+				{token.SEMICOLON, ";"},
+				{token.SEMICOLON, ";"},
+				{token.RPAREN, ")"},
+				{token.LBRACE, "{"},
+				{token.CONTINUE, "continue"},
+				{token.SEMICOLON, ";"},
+				{token.RBRACE, "}"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"x = 1; x += 1; x -= 1; x *= 1; x /= 1;",
+			[]TokenTest{
+				{token.IDENT, "x"},
+				{token.ASSIGN, "="},
+				{token.INT, "1"},
+				{token.SEMICOLON, ";"},
+				{token.IDENT, "x"},
+				{token.PLUS_ASSIGN, "+="},
+				{token.INT, "1"},
+				{token.SEMICOLON, ";"},
+				{token.IDENT, "x"},
+				{token.MINUS_ASSIGN, "-="},
+				{token.INT, "1"},
+				{token.SEMICOLON, ";"},
+				{token.IDENT, "x"},
+				{token.ASTERISK_ASSIGN, "*="},
+				{token.INT, "1"},
+				{token.SEMICOLON, ";"},
+				{token.IDENT, "x"},
+				{token.SLASH_ASSIGN, "/="},
+				{token.INT, "1"},
+				{token.SEMICOLON, ";"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"a && b || c & d | e",
+			[]TokenTest{
+				{token.IDENT, "a"},
+				{token.AND, "&&"},
+				{token.IDENT, "b"},
+				{token.OR, "||"},
+				{token.IDENT, "c"},
+				{token.ILLEGAL, "&"},
+				{token.IDENT, "d"},
+				{token.ILLEGAL, "|"},
+				{token.IDENT, "e"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"a <= b >= c % d ** e *= f",
+			[]TokenTest{
+				{token.IDENT, "a"},
+				{token.LT_EQ, "<="},
+				{token.IDENT, "b"},
+				{token.GT_EQ, ">="},
+				{token.IDENT, "c"},
+				{token.PERCENT, "%"},
+				{token.IDENT, "d"},
+				{token.POWER, "**"},
+				{token.IDENT, "e"},
+				{token.ASTERISK_ASSIGN, "*="},
+				{token.IDENT, "f"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"3.14 1e-3 2.5E+10 7e2 1. 1.x 2e",
+			[]TokenTest{
+				{token.FLOAT, "3.14"},
+				{token.FLOAT, "1e-3"},
+				{token.FLOAT, "2.5E+10"},
+				{token.FLOAT, "7e2"},
+				{token.INT, "1"},
+				{token.ILLEGAL, "."},
+				{token.INT, "1"},
+				{token.ILLEGAL, "."},
+				{token.IDENT, "x"},
+				{token.ILLEGAL, "2e"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"\"a\\tb\\nc\\\\d\\\"e\" \"\\u{48}\\u{1F600}\"",
+			[]TokenTest{
+				{token.STRING, "a\tb\nc\\d\"e"},
+				{token.STRING, "H\U0001F600"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"`raw \\n\nstring` \"x\\qy\" \"\\u{110000}\" \"open",
+			[]TokenTest{
+				{token.STRING, "raw \\n\nstring"},
+				{token.ILLEGAL, "\\q"},
+				{token.ILLEGAL, "\\u{110000}"},
+				{token.ILLEGAL, "\"open"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"\"a ${b + {}[\"c\"]} d ${\"${e}\"}\\${f}\"",
+			[]TokenTest{
+				{token.STRING_HEAD, "a "},
+				{token.IDENT, "b"},
+				{token.PLUS, "+"},
+				{token.LBRACE, "{"},
+				{token.RBRACE, "}"},
+				{token.LBRACKET, "["},
+				{token.STRING, "c"},
+				{token.RBRACKET, "]"},
+				{token.STRING_MIDDLE, " d "},
+				{token.STRING_HEAD, ""},
+				{token.IDENT, "e"},
+				{token.STRING_TAIL, ""},
+				{token.STRING_TAIL, "${f}"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"a // line comment\n/ b /* block /* nested */ still comment */ c /= d // eof",
+			[]TokenTest{
+				{token.IDENT, "a"},
+				{token.SLASH, "/"},
+				{token.IDENT, "b"},
+				{token.IDENT, "c"},
+				{token.SLASH_ASSIGN, "/="},
+				{token.IDENT, "d"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"a /* open /* nested */",
+			[]TokenTest{
+				{token.IDENT, "a"},
+				{token.ILLEGAL, "/* open /* nested */"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"let x1 = données_2 + 名前; empty? done! a!=b ∆",
+			[]TokenTest{
+				{token.LET, "let"},
+				{token.IDENT, "x1"},
+				{token.ASSIGN, "="},
+				{token.IDENT, "données_2"},
+				{token.PLUS, "+"},
+				{token.IDENT, "名前"},
+				{token.SEMICOLON, ";"},
+				{token.IDENT, "empty?"},
+				{token.IDENT, "done!"},
+				{token.IDENT, "a"},
+				{token.NOT_EQ, "!="},
+				{token.IDENT, "b"},
+				{token.ILLEGAL, "∆"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"0xFF 0o17 0B101 1_000_000 1_0.5_0e1_0 0x1G 0b 7_",
+			[]TokenTest{
+				{token.INT, "0xFF"},
+				{token.INT, "0o17"},
+				{token.INT, "0B101"},
+				{token.INT, "1_000_000"},
+				{token.FLOAT, "1_0.5_0e1_0"},
+				{token.INT, "0x1G"},
+				{token.INT, "0b"},
+				{token.INT, "7_"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			"1e 1e+ 2.5E-x 3e5",
+			[]TokenTest{
+				{token.ILLEGAL, "1e"},
+				{token.ILLEGAL, "1e+"},
+				{token.ILLEGAL, "2.5E-"},
+				{token.IDENT, "x"},
+				{token.FLOAT, "3e5"},
+				{token.EOF, ""},
+			},
+		},
 	}
 
 	for i, test := range tests {
@@ -307,6 +555,80 @@ func TestNextToken(t *testing.T) {
 	}
 }
 
+func TestComments(t *testing.T) {
+	input := "// first\nlet x = /* second /* nested */ */ 1; // third"
+	expected := []token.Token{
+		{Type: token.COMMENT, Lexeme: "// first", Offset: 0, Length: 8, Line: 1, Column: 1},
+		{Type: token.COMMENT, Lexeme: "/* second /* nested */ */", Offset: 17, Length: 25, Line: 2, Column: 9},
+		{Type: token.COMMENT, Lexeme: "// third", Offset: 46, Length: 8, Line: 2, Column: 38},
+	}
+
+	l := NewLexer(input)
+	for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+	}
+
+	actual := l.Comments()
+	if len(expected) != len(actual) {
+		t.Fatalf("len(l.Comments()) ==> expected: %d actual: %d", len(expected), len(actual))
+	}
+
+	for i, comment := range actual {
+		if expected[i] != comment {
+			t.Errorf("l.Comments()[%d] ==> expected: %+v actual: %+v", i, expected[i], comment)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		offset   int
+		expected string
+	}{
+		{"1e", 0, "malformed exponent"},
+		{"x = 12.5e+;", 4, "malformed exponent"},
+		{"\"open", 0, "unterminated string"},
+	}
+
+	for i, test := range tests {
+		l := NewLexer(test.input)
+		for tok := l.NextToken(); tok.Type != token.EOF; tok = l.NextToken() {
+		}
+
+		msg, ok := l.Error(test.offset)
+		if !ok {
+			t.Errorf("test[%d] - %q - l.Error(%d) ==> expected: %q actual: none", i, test.input, test.offset, test.expected)
+			continue
+		}
+
+		if test.expected != msg {
+			t.Errorf("test[%d] - %q - l.Error(%d) ==> expected: %q actual: %q", i, test.input, test.offset, test.expected, msg)
+		}
+	}
+}
+
+func TestTokenPositions(t *testing.T) {
+	input := "é = \"ü\" +\n∆x;"
+	expected := []token.Token{
+		{Type: token.IDENT, Lexeme: "é", Offset: 0, Length: 2, Line: 1, Column: 1},
+		{Type: token.ASSIGN, Lexeme: "=", Offset: 3, Length: 1, Line: 1, Column: 4},
+		{Type: token.STRING, Lexeme: "ü", Offset: 5, Length: 4, Line: 1, Column: 6},
+		{Type: token.PLUS, Lexeme: "+", Offset: 10, Length: 1, Line: 1, Column: 11},
+		{Type: token.ILLEGAL, Lexeme: "∆", Offset: 12, Length: 3, Line: 2, Column: 1},
+		{Type: token.IDENT, Lexeme: "x", Offset: 15, Length: 1, Line: 2, Column: 4},
+		{Type: token.SEMICOLON, Lexeme: ";", Offset: 16, Length: 1, Line: 2, Column: 5},
+		{Type: token.EOF, Lexeme: "", Offset: 17, Length: 0, Line: 2, Column: 6},
+	}
+
+	l := NewLexer(input)
+	for i, tok := range expected {
+		actual := l.NextToken()
+		if tok != actual {
+			t.Errorf("tokens[%d] ==> expected: %+v actual: %+v", i, tok, actual)
+		}
+	}
+}
+
 func testTokens(t *testing.T, idx int, input string, tests []TokenTest) bool {
 	l := NewLexer(input)
 	for j, expected := range tests {
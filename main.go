@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
@@ -8,12 +9,19 @@ import (
 )
 
 func main() {
-	if len(os.Args) > 2 {
-		fmt.Println("Usage: monkey [script]")
+	strict := flag.Bool("strict", false, "report out of range indexes and missing hash keys as errors")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: monkey [-strict] [script]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() > 1 {
+		flag.Usage()
 		os.Exit(64)
-	} else if len(os.Args) == 2 {
-		repl.Script(os.Args[1], os.Stdout)
+	} else if flag.NArg() == 1 {
+		repl.Script(flag.Arg(0), os.Stdout, *strict)
 	} else {
-		repl.Start(os.Stdin, os.Stdout)
+		repl.Start(os.Stdin, os.Stdout, *strict)
 	}
 }
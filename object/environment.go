@@ -1,14 +1,23 @@
 package object
 
+// DEFAULT_MAX_DEPTH bounds the number of nested function calls, well below the
+// depth at which the evaluator would exhaust the Go stack.
+const DEFAULT_MAX_DEPTH = 10000
+
 type Environment struct {
 	store map[string]Object
 	outer *Environment
+
+	strict   bool
+	maxDepth int
+	calls    []string
 }
 
 func NewEnvironment() *Environment {
 	return &Environment{
-		store: make(map[string]Object),
-		outer: nil,
+		store:    make(map[string]Object),
+		outer:    nil,
+		maxDepth: DEFAULT_MAX_DEPTH,
 	}
 }
 
@@ -19,6 +28,68 @@ func NewEnclosedEnvironment(outer *Environment) *Environment {
 	}
 }
 
+// SetStrict switches strict mode on or off for the outermost environment, and
+// so for every scope enclosed by it. In strict mode, out of range indexes and
+// missing hash keys are errors instead of evaluating to null.
+func (e *Environment) SetStrict(strict bool) {
+	if e.outer != nil {
+		e.outer.SetStrict(strict)
+		return
+	}
+	e.strict = strict
+}
+
+func (e *Environment) Strict() bool {
+	if e.outer != nil {
+		return e.outer.Strict()
+	}
+	return e.strict
+}
+
+// SetMaxDepth sets the number of nested function calls allowed in the
+// outermost environment before a call fails with an error.
+func (e *Environment) SetMaxDepth(depth int) {
+	if e.outer != nil {
+		e.outer.SetMaxDepth(depth)
+		return
+	}
+	e.maxDepth = depth
+}
+
+func (e *Environment) MaxDepth() int {
+	if e.outer != nil {
+		return e.outer.MaxDepth()
+	}
+	return e.maxDepth
+}
+
+// PushCall records a call to the named function on the call stack of the
+// outermost environment.
+func (e *Environment) PushCall(name string) {
+	if e.outer != nil {
+		e.outer.PushCall(name)
+		return
+	}
+	e.calls = append(e.calls, name)
+}
+
+func (e *Environment) PopCall() {
+	if e.outer != nil {
+		e.outer.PopCall()
+		return
+	}
+	e.calls = e.calls[:len(e.calls)-1]
+}
+
+// Calls returns the names of the functions currently being called, outermost
+// first.
+func (e *Environment) Calls() []string {
+	if e.outer != nil {
+		return e.outer.Calls()
+	}
+	return e.calls
+}
+
 func (e *Environment) Get(name string) (Object, bool) {
 	obj, ok := e.store[name]
 	if !ok && e.outer != nil {
@@ -30,3 +101,16 @@ func (e *Environment) Get(name string) (Object, bool) {
 func (e *Environment) Set(name string, obj Object) {
 	e.store[name] = obj
 }
+
+func (e *Environment) Assign(name string, obj Object) bool {
+	if _, ok := e.store[name]; ok {
+		e.store[name] = obj
+		return true
+	}
+
+	if e.outer != nil {
+		return e.outer.Assign(name, obj)
+	}
+
+	return false
+}
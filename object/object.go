@@ -4,13 +4,19 @@ import (
 	"bytes"
 	"fmt"
 	"hash/fnv"
+	"math"
+	"math/big"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/eugene-whitaker/writing-an-interpreter-in-go/ast"
+	"github.com/eugene-whitaker/writing-an-interpreter-in-go/token"
 )
 
 const (
 	INTEGER_OBJECT      = "INTEGER"
+	FLOAT_OBJECT        = "FLOAT"
 	BOOLEAN_OBJECT      = "BOOLEAN"
 	NULL_OBJECT         = "NULL"
 	RETURN_VALUE_OBJECT = "RETURN_VALUE"
@@ -22,6 +28,9 @@ const (
 	HASH_OBJECT         = "HASH"
 	QUOTE_OBJECT        = "QUOTE"
 	MACRO_OBJECT        = "MACRO"
+	BREAK_OBJECT        = "BREAK"
+	CONTINUE_OBJECT     = "CONTINUE"
+	TAIL_CALL_OBJECT    = "TAIL_CALL"
 )
 
 type ObjectType string
@@ -29,9 +38,13 @@ type ObjectType string
 type Object interface {
 	Type() ObjectType
 	Inspect() string
+	// Truthy reports whether the value counts as true in a condition: false,
+	// null, zero and empty strings, arrays and hashes are falsy.
+	Truthy() bool
 }
 
 type Hashable interface {
+	Object
 	HashKey() HashKey
 }
 
@@ -52,6 +65,10 @@ func (i *Integer) Inspect() string {
 	return fmt.Sprintf("%d", i.Value)
 }
 
+func (i *Integer) Truthy() bool {
+	return i.Value != 0
+}
+
 func (i *Integer) HashKey() HashKey {
 	return HashKey{
 		Type:  i.Type(),
@@ -59,6 +76,73 @@ func (i *Integer) HashKey() HashKey {
 	}
 }
 
+// BigInteger holds integers that overflow int64. It reports the same type as
+// Integer, and the evaluator demotes results back to Integer whenever they fit.
+type BigInteger struct {
+	Value *big.Int
+}
+
+func (bi *BigInteger) Type() ObjectType {
+	return INTEGER_OBJECT
+}
+
+func (bi *BigInteger) Inspect() string {
+	return bi.Value.String()
+}
+
+func (bi *BigInteger) Truthy() bool {
+	return bi.Value.Sign() != 0
+}
+
+func (bi *BigInteger) HashKey() HashKey {
+	if bi.Value.IsInt64() {
+		return (&Integer{Value: bi.Value.Int64()}).HashKey()
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte{byte(bi.Value.Sign() + 1)})
+	h.Write(bi.Value.Bytes())
+
+	return HashKey{
+		Type:  bi.Type(),
+		Value: h.Sum64(),
+	}
+}
+
+type Float struct {
+	Value float64
+}
+
+func (f *Float) Type() ObjectType {
+	return FLOAT_OBJECT
+}
+
+func (f *Float) Inspect() string {
+	s := strconv.FormatFloat(f.Value, 'g', -1, 64)
+	if strings.ContainsAny(s, ".eIN") {
+		return s
+	}
+	return s + ".0"
+}
+
+func (f *Float) Truthy() bool {
+	return f.Value != 0
+}
+
+// Integral floats hash like the equal integer so that 1 and 1.0 address the
+// same hash entry, matching 1 == 1.0.
+func (f *Float) HashKey() HashKey {
+	if f.Value == math.Trunc(f.Value) && !math.IsInf(f.Value, 0) {
+		i, _ := big.NewFloat(f.Value).Int(nil)
+		return (&BigInteger{Value: i}).HashKey()
+	}
+
+	return HashKey{
+		Type:  f.Type(),
+		Value: math.Float64bits(f.Value),
+	}
+}
+
 type Boolean struct {
 	Value bool
 }
@@ -71,6 +155,10 @@ func (b *Boolean) Inspect() string {
 	return fmt.Sprintf("%t", b.Value)
 }
 
+func (b *Boolean) Truthy() bool {
+	return b.Value
+}
+
 func (b *Boolean) HashKey() HashKey {
 	var v uint64
 
@@ -96,6 +184,10 @@ func (n *Null) Inspect() string {
 	return "null"
 }
 
+func (n *Null) Truthy() bool {
+	return false
+}
+
 type ReturnValue struct {
 	Value Object
 }
@@ -108,8 +200,73 @@ func (rv *ReturnValue) Inspect() string {
 	return rv.Value.Inspect()
 }
 
+func (rv *ReturnValue) Truthy() bool {
+	return rv.Value.Truthy()
+}
+
+type Break struct{}
+
+func (b *Break) Type() ObjectType {
+	return BREAK_OBJECT
+}
+
+func (b *Break) Inspect() string {
+	return "break"
+}
+
+func (b *Break) Truthy() bool {
+	return true
+}
+
+type Continue struct{}
+
+func (c *Continue) Type() ObjectType {
+	return CONTINUE_OBJECT
+}
+
+func (c *Continue) Inspect() string {
+	return "continue"
+}
+
+func (c *Continue) Truthy() bool {
+	return true
+}
+
+// TailCall is a call in tail position that has been evaluated up to, but not
+// including, the call itself. It is handed back to the enclosing function call
+// so the call can reuse its Go stack frame.
+type TailCall struct {
+	Token     token.Token // The '(' token of the call
+	Function  *Function
+	Arguments []Object
+}
+
+func (tc *TailCall) Type() ObjectType {
+	return TAIL_CALL_OBJECT
+}
+
+func (tc *TailCall) Inspect() string {
+	return "tail call"
+}
+
+func (tc *TailCall) Truthy() bool {
+	return true
+}
+
+// Error is a runtime error; Line and Column are 0 until it is attributed to a node.
 type Error struct {
 	Message string
+	Line    int
+	Column  int
+	Stack   []Frame // The calls the error propagated out of, innermost first
+	Value   Object  // The value thrown by a throw statement, nil otherwise
+}
+
+// Frame is a function call on the Monkey call stack, located at its call site.
+type Frame struct {
+	Function string
+	Line     int
+	Column   int
 }
 
 func (e *Error) Type() ObjectType {
@@ -120,8 +277,15 @@ func (e *Error) Inspect() string {
 	return "ERROR: " + e.Message
 }
 
+func (e *Error) Truthy() bool {
+	return true
+}
+
 type Function struct {
+	Name       string
 	Parameters []*ast.Identifier
+	Defaults   []ast.Expression
+	Rest       *ast.Identifier
 	Body       *ast.BlockStatement
 	Env        *Environment
 }
@@ -134,8 +298,15 @@ func (f *Function) Inspect() string {
 	var out bytes.Buffer
 
 	ps := []string{}
-	for _, p := range f.Parameters {
-		ps = append(ps, p.String())
+	for i, p := range f.Parameters {
+		if i < len(f.Defaults) && f.Defaults[i] != nil {
+			ps = append(ps, p.String()+" = "+f.Defaults[i].String())
+		} else {
+			ps = append(ps, p.String())
+		}
+	}
+	if f.Rest != nil {
+		ps = append(ps, "..."+f.Rest.String())
 	}
 
 	out.WriteString("fn(")
@@ -147,8 +318,24 @@ func (f *Function) Inspect() string {
 	return out.String()
 }
 
+func (f *Function) Truthy() bool {
+	return true
+}
+
 type String struct {
 	Value string
+
+	runes   int
+	counted bool
+}
+
+// Length returns the number of runes in Value, counting them only once.
+func (s *String) Length() int {
+	if !s.counted {
+		s.runes = utf8.RuneCountInString(s.Value)
+		s.counted = true
+	}
+	return s.runes
 }
 
 func (s *String) Type() ObjectType {
@@ -159,6 +346,10 @@ func (s *String) Inspect() string {
 	return s.Value
 }
 
+func (s *String) Truthy() bool {
+	return s.Value != ""
+}
+
 func (s *String) HashKey() HashKey {
 	h := fnv.New64a()
 	h.Write([]byte(s.Value))
@@ -183,6 +374,10 @@ func (b *Builtin) Inspect() string {
 	return "builtin function"
 }
 
+func (b *Builtin) Truthy() bool {
+	return true
+}
+
 type Array struct {
 	Elements []Object
 }
@@ -192,11 +387,39 @@ func (a *Array) Type() ObjectType {
 }
 
 func (a *Array) Inspect() string {
+	return inspect(a, map[Object]bool{})
+}
+
+// inspect prints obj, descending into arrays and hashes itself so it can print
+// a container that contains itself as [...] or {...} instead of recursing
+// forever.
+func inspect(obj Object, seen map[Object]bool) string {
+	switch obj := obj.(type) {
+	case *Array:
+		if seen[obj] {
+			return "[...]"
+		}
+		seen[obj] = true
+		defer delete(seen, obj)
+		return obj.inspect(seen)
+	case *Hash:
+		if seen[obj] {
+			return "{...}"
+		}
+		seen[obj] = true
+		defer delete(seen, obj)
+		return obj.inspect(seen)
+	default:
+		return obj.Inspect()
+	}
+}
+
+func (a *Array) inspect(seen map[Object]bool) string {
 	var out bytes.Buffer
 
 	es := []string{}
 	for _, e := range a.Elements {
-		es = append(es, e.Inspect())
+		es = append(es, inspect(e, seen))
 	}
 
 	out.WriteString("[")
@@ -206,13 +429,102 @@ func (a *Array) Inspect() string {
 	return out.String()
 }
 
+func (a *Array) Truthy() bool {
+	return len(a.Elements) != 0
+}
+
 type HashPair struct {
 	Key   Object
 	Value Object
 }
 
+// Hash keeps its pairs in insertion order; add them with Set to keep buckets in step.
 type Hash struct {
-	Pairs map[HashKey]HashPair
+	Pairs []HashPair
+
+	// Hasher overrides Hashable.HashKey when set, e.g. to force collisions.
+	Hasher func(Hashable) HashKey
+
+	buckets map[HashKey][]int
+}
+
+func (h *Hash) Get(key Hashable) (Object, bool) {
+	if i, ok := h.find(key); ok {
+		return h.Pairs[i].Value, true
+	}
+	return nil, false
+}
+
+func (h *Hash) Set(key Hashable, value Object) {
+	if i, ok := h.find(key); ok {
+		h.Pairs[i].Value = value
+		return
+	}
+
+	hashKey := h.hashKey(key)
+	h.buckets[hashKey] = append(h.buckets[hashKey], len(h.Pairs))
+	h.Pairs = append(h.Pairs, HashPair{Key: key, Value: value})
+}
+
+func (h *Hash) find(key Hashable) (int, bool) {
+	if h.buckets == nil {
+		h.buckets = map[HashKey][]int{}
+		for i, pair := range h.Pairs {
+			if hashable, ok := pair.Key.(Hashable); ok {
+				hashKey := h.hashKey(hashable)
+				h.buckets[hashKey] = append(h.buckets[hashKey], i)
+			}
+		}
+	}
+
+	for _, i := range h.buckets[h.hashKey(key)] {
+		if keysEqual(h.Pairs[i].Key, key) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (h *Hash) hashKey(key Hashable) HashKey {
+	if h.Hasher != nil {
+		return h.Hasher(key)
+	}
+	return key.HashKey()
+}
+
+// keysEqual compares hash keys by value, treating numbers of any type as
+// equal when they are numerically equal, to match how they hash.
+func keysEqual(left, right Object) bool {
+	switch left := left.(type) {
+	case *String:
+		right, ok := right.(*String)
+		return ok && left.Value == right.Value
+	case *Boolean:
+		right, ok := right.(*Boolean)
+		return ok && left.Value == right.Value
+	case *Integer, *BigInteger, *Float:
+		l, lok := toBigFloat(left)
+		r, rok := toBigFloat(right)
+		return lok && rok && l.Cmp(r) == 0
+	default:
+		return left == right
+	}
+}
+
+func toBigFloat(obj Object) (*big.Float, bool) {
+	switch obj := obj.(type) {
+	case *Integer:
+		return new(big.Float).SetInt64(obj.Value), true
+	case *BigInteger:
+		return new(big.Float).SetInt(obj.Value), true
+	case *Float:
+		if math.IsNaN(obj.Value) {
+			return nil, false
+		}
+		return big.NewFloat(obj.Value), true
+	default:
+		return nil, false
+	}
 }
 
 func (h *Hash) Type() ObjectType {
@@ -220,11 +532,15 @@ func (h *Hash) Type() ObjectType {
 }
 
 func (h *Hash) Inspect() string {
+	return inspect(h, map[Object]bool{})
+}
+
+func (h *Hash) inspect(seen map[Object]bool) string {
 	var out bytes.Buffer
 
 	ps := []string{}
 	for _, p := range h.Pairs {
-		ps = append(ps, p.Key.Inspect()+":"+p.Value.Inspect())
+		ps = append(ps, inspect(p.Key, seen)+":"+inspect(p.Value, seen))
 	}
 
 	out.WriteString("{")
@@ -234,6 +550,10 @@ func (h *Hash) Inspect() string {
 	return out.String()
 }
 
+func (h *Hash) Truthy() bool {
+	return len(h.Pairs) != 0
+}
+
 type Quote struct {
 	Node ast.Node
 }
@@ -246,6 +566,10 @@ func (q *Quote) Inspect() string {
 	return "quote(" + q.Node.String() + ")"
 }
 
+func (q *Quote) Truthy() bool {
+	return true
+}
+
 type Macro struct {
 	Parameters []*ast.Identifier
 	Body       *ast.BlockStatement
@@ -272,3 +596,7 @@ func (m *Macro) Inspect() string {
 
 	return out.String()
 }
+
+func (m *Macro) Truthy() bool {
+	return true
+}
@@ -1,6 +1,10 @@
 package object
 
-import "testing"
+import (
+	"math"
+	"math/big"
+	"testing"
+)
 
 type HashKeyTest struct {
 	expected   Hashable
@@ -43,6 +47,50 @@ func TestHashKey(t *testing.T) {
 				Value: 2,
 			},
 		},
+		{
+			&Float{
+				Value: 1.5,
+			},
+			&Float{
+				Value: 1.5,
+			},
+			&Float{
+				Value: 2.5,
+			},
+		},
+		{
+			&Integer{
+				Value: 2,
+			},
+			&Float{
+				Value: 2.0,
+			},
+			&Float{
+				Value: 2.5,
+			},
+		},
+		{
+			&Integer{
+				Value: 1,
+			},
+			&BigInteger{
+				Value: big.NewInt(1),
+			},
+			&BigInteger{
+				Value: new(big.Int).Lsh(big.NewInt(1), 64),
+			},
+		},
+		{
+			&BigInteger{
+				Value: new(big.Int).Lsh(big.NewInt(1), 64),
+			},
+			&Float{
+				Value: 18446744073709551616,
+			},
+			&BigInteger{
+				Value: new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 64)),
+			},
+		},
 	}
 
 	for i, test := range tests {
@@ -57,3 +105,165 @@ func TestHashKey(t *testing.T) {
 		}
 	}
 }
+
+func TestTruthy(t *testing.T) {
+	tests := []struct {
+		obj      Object
+		expected bool
+	}{
+		{&Integer{Value: 0}, false},
+		{&Integer{Value: -1}, true},
+		{&BigInteger{Value: new(big.Int)}, false},
+		{&BigInteger{Value: new(big.Int).Lsh(big.NewInt(1), 64)}, true},
+		{&Float{Value: 0}, false},
+		{&Float{Value: 0.5}, true},
+		{&Boolean{Value: false}, false},
+		{&Boolean{Value: true}, true},
+		{&Null{}, false},
+		{&String{Value: ""}, false},
+		{&String{Value: "a"}, true},
+		{&Array{Elements: []Object{}}, false},
+		{&Array{}, false},
+		{&Array{Elements: []Object{&Null{}}}, true},
+		{&Hash{Pairs: []HashPair{}}, false},
+		{&Hash{}, false},
+		{&Hash{Pairs: []HashPair{{Key: &Null{}, Value: &Null{}}}}, true},
+		{&ReturnValue{Value: &Integer{Value: 0}}, false},
+		{&ReturnValue{Value: &Integer{Value: 1}}, true},
+		{&Error{Message: ""}, true},
+		{&Function{}, true},
+		{&Builtin{}, true},
+		{&Quote{}, true},
+		{&Macro{}, true},
+		{&Break{}, true},
+		{&Continue{}, true},
+	}
+
+	for i, test := range tests {
+		if test.expected != test.obj.Truthy() {
+			t.Errorf("test[%d] - %T.Truthy() ==> expected: %t actual: %t", i, test.obj, test.expected, test.obj.Truthy())
+		}
+	}
+}
+
+func TestHashOrder(t *testing.T) {
+	hash := &Hash{}
+
+	keys := []Hashable{
+		&String{Value: "b"},
+		&Integer{Value: 1},
+		&String{Value: "a"},
+		&Integer{Value: 1},
+	}
+	for i, key := range keys {
+		hash.Set(key, &Integer{Value: int64(i)})
+	}
+
+	expected := "{b:0, 1:3, a:2}"
+	if expected != hash.Inspect() {
+		t.Errorf("hash.Inspect() ==> expected: %q actual: %q", expected, hash.Inspect())
+	}
+}
+
+func TestHashCollisions(t *testing.T) {
+	hash := &Hash{
+		Hasher: func(Hashable) HashKey {
+			return HashKey{Type: STRING_OBJECT, Value: 42}
+		},
+	}
+
+	hash.Set(&String{Value: "a"}, &Integer{Value: 1})
+	hash.Set(&String{Value: "b"}, &Integer{Value: 2})
+	hash.Set(&Integer{Value: 1}, &Integer{Value: 3})
+	hash.Set(&Float{Value: 1.0}, &Integer{Value: 4})
+	hash.Set(&Boolean{Value: true}, &Integer{Value: 5})
+	hash.Set(&BigInteger{Value: new(big.Int).Lsh(big.NewInt(1), 64)}, &Integer{Value: 6})
+	hash.Set(&String{Value: "a"}, &Integer{Value: 7})
+
+	tests := []struct {
+		key      Hashable
+		expected int64
+	}{
+		{&String{Value: "a"}, 7},
+		{&String{Value: "b"}, 2},
+		{&Integer{Value: 1}, 4},
+		{&Float{Value: 1.0}, 4},
+		{&BigInteger{Value: big.NewInt(1)}, 4},
+		{&Boolean{Value: true}, 5},
+		{&Float{Value: 18446744073709551616}, 6},
+	}
+
+	for i, test := range tests {
+		value, ok := hash.Get(test.key)
+		if !ok {
+			t.Errorf("test[%d] - hash.Get(%s) ==> expected: found", i, test.key.Inspect())
+			continue
+		}
+
+		if test.expected != value.(*Integer).Value {
+			t.Errorf("test[%d] - hash.Get(%s) ==> expected: %d actual: %s", i, test.key.Inspect(), test.expected, value.Inspect())
+		}
+	}
+
+	missing := []Hashable{
+		&String{Value: "c"},
+		&Boolean{Value: false},
+		&Float{Value: math.NaN()},
+	}
+
+	for i, key := range missing {
+		if value, ok := hash.Get(key); ok {
+			t.Errorf("missing[%d] - hash.Get(%s) ==> expected: not found actual: %s", i, key.Inspect(), value.Inspect())
+		}
+	}
+
+	expected := "{a:7, b:2, 1:4, true:5, 18446744073709551616:6}"
+	if expected != hash.Inspect() {
+		t.Errorf("hash.Inspect() ==> expected: %q actual: %q", expected, hash.Inspect())
+	}
+}
+
+func TestInspectCycles(t *testing.T) {
+	array := &Array{Elements: []Object{&Integer{Value: 1}}}
+	array.Elements = append(array.Elements, array)
+
+	hash := &Hash{}
+	hash.Set(&String{Value: "self"}, hash)
+	hash.Set(&String{Value: "array"}, array)
+
+	shared := &Array{Elements: []Object{&Integer{Value: 2}}}
+
+	tests := []struct {
+		obj      Object
+		expected string
+	}{
+		{array, "[1, [...]]"},
+		{hash, "{self:{...}, array:[1, [...]]}"},
+		{&Array{Elements: []Object{shared, shared}}, "[[2], [2]]"},
+	}
+
+	for i, test := range tests {
+		if test.expected != test.obj.Inspect() {
+			t.Errorf("test[%d] - %T.Inspect() ==> expected: %q actual: %q", i, test.obj, test.expected, test.obj.Inspect())
+		}
+	}
+}
+
+func TestStringLength(t *testing.T) {
+	tests := []struct {
+		str      *String
+		expected int
+	}{
+		{&String{Value: ""}, 0},
+		{&String{Value: "hello"}, 5},
+		{&String{Value: "héllo, 世界"}, 9},
+	}
+
+	for i, test := range tests {
+		for n := 0; n < 2; n++ {
+			if test.expected != test.str.Length() {
+				t.Errorf("test[%d] - %q.Length() ==> expected: %d actual: %d", i, test.str.Value, test.expected, test.str.Length())
+			}
+		}
+	}
+}
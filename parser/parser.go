@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
@@ -13,11 +15,15 @@ import (
 const (
 	_ int = iota
 	LOWEST
+	ASSIGN      // X = X or X += X
+	OR          // X || X
+	AND         // X && X
 	EQUALS      // X == X
 	LESSGREATER // X > X or X < X
 	SUM         // X + X
 	PRODUCT     // X * X
 	PREFIX      // -X or !X
+	POWER       // X ** X
 	CALL        // func(X)
 	INDEX       // arr[X]
 )
@@ -35,6 +41,8 @@ type Parser struct {
 
 	current int
 
+	loops int
+
 	prefixFuncs map[token.TokenType]prefixFunc
 	infixFuncs  map[token.TokenType]infixFunc
 
@@ -42,16 +50,27 @@ type Parser struct {
 }
 
 var precedences = map[token.TokenType]int{
-	token.EQ:       EQUALS,
-	token.NOT_EQ:   EQUALS,
-	token.LT:       LESSGREATER,
-	token.GT:       LESSGREATER,
-	token.PLUS:     SUM,
-	token.MINUS:    SUM,
-	token.SLASH:    PRODUCT,
-	token.ASTERISK: PRODUCT,
-	token.LPAREN:   CALL,
-	token.LBRACKET: INDEX,
+	token.ASSIGN:          ASSIGN,
+	token.PLUS_ASSIGN:     ASSIGN,
+	token.MINUS_ASSIGN:    ASSIGN,
+	token.ASTERISK_ASSIGN: ASSIGN,
+	token.SLASH_ASSIGN:    ASSIGN,
+	token.OR:              OR,
+	token.AND:             AND,
+	token.EQ:              EQUALS,
+	token.NOT_EQ:          EQUALS,
+	token.LT:              LESSGREATER,
+	token.GT:              LESSGREATER,
+	token.LT_EQ:           LESSGREATER,
+	token.GT_EQ:           LESSGREATER,
+	token.PLUS:            SUM,
+	token.MINUS:           SUM,
+	token.SLASH:           PRODUCT,
+	token.ASTERISK:        PRODUCT,
+	token.PERCENT:         PRODUCT,
+	token.POWER:           POWER,
+	token.LPAREN:          CALL,
+	token.LBRACKET:        INDEX,
 }
 
 func NewParser(l *lexer.Lexer) *Parser {
@@ -69,32 +88,45 @@ func NewParser(l *lexer.Lexer) *Parser {
 	p.tok = token.Token{}
 
 	p.prefixFuncs = map[token.TokenType]prefixFunc{
-		token.IDENT:    p.parseIdentifier,
-		token.INT:      p.parseIntegerLiteral,
-		token.BANG:     p.parsePrefixExpression,
-		token.MINUS:    p.parsePrefixExpression,
-		token.TRUE:     p.parseBooleanLiteral,
-		token.FALSE:    p.parseBooleanLiteral,
-		token.LPAREN:   p.parseGroupedExpression,
-		token.IF:       p.parseIfExpression,
-		token.FUNCTION: p.parseFunctionLiteral,
-		token.STRING:   p.parseStringLiteral,
-		token.LBRACKET: p.parseArrayLiteral,
-		token.LBRACE:   p.parseHashLiteral,
-		token.MACRO:    p.parseMacroExpression,
+		token.IDENT:       p.parseIdentifier,
+		token.INT:         p.parseIntegerLiteral,
+		token.FLOAT:       p.parseFloatLiteral,
+		token.BANG:        p.parsePrefixExpression,
+		token.MINUS:       p.parsePrefixExpression,
+		token.TRUE:        p.parseBooleanLiteral,
+		token.FALSE:       p.parseBooleanLiteral,
+		token.LPAREN:      p.parseGroupedExpression,
+		token.IF:          p.parseIfExpression,
+		token.FUNCTION:    p.parseFunctionLiteral,
+		token.STRING:      p.parseStringLiteral,
+		token.STRING_HEAD: p.parseInterpolatedString,
+		token.LBRACKET:    p.parseArrayLiteral,
+		token.LBRACE:      p.parseHashLiteral,
+		token.MACRO:       p.parseMacroExpression,
 	}
 
 	p.infixFuncs = map[token.TokenType]infixFunc{
-		token.PLUS:     p.parseInfixExpression,
-		token.MINUS:    p.parseInfixExpression,
-		token.SLASH:    p.parseInfixExpression,
-		token.ASTERISK: p.parseInfixExpression,
-		token.EQ:       p.parseInfixExpression,
-		token.NOT_EQ:   p.parseInfixExpression,
-		token.LT:       p.parseInfixExpression,
-		token.GT:       p.parseInfixExpression,
-		token.LPAREN:   p.parseCallExpression,
-		token.LBRACKET: p.parseIndexExpression,
+		token.PLUS:            p.parseInfixExpression,
+		token.MINUS:           p.parseInfixExpression,
+		token.SLASH:           p.parseInfixExpression,
+		token.ASTERISK:        p.parseInfixExpression,
+		token.EQ:              p.parseInfixExpression,
+		token.NOT_EQ:          p.parseInfixExpression,
+		token.AND:             p.parseInfixExpression,
+		token.OR:              p.parseInfixExpression,
+		token.LT:              p.parseInfixExpression,
+		token.GT:              p.parseInfixExpression,
+		token.LT_EQ:           p.parseInfixExpression,
+		token.GT_EQ:           p.parseInfixExpression,
+		token.PERCENT:         p.parseInfixExpression,
+		token.POWER:           p.parseInfixExpression,
+		token.LPAREN:          p.parseCallExpression,
+		token.LBRACKET:        p.parseIndexExpression,
+		token.ASSIGN:          p.parseAssignExpression,
+		token.PLUS_ASSIGN:     p.parseAssignExpression,
+		token.MINUS_ASSIGN:    p.parseAssignExpression,
+		token.ASTERISK_ASSIGN: p.parseAssignExpression,
+		token.SLASH_ASSIGN:    p.parseAssignExpression,
 	}
 
 	p.errors = []string{}
@@ -132,6 +164,18 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseLetStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.WHILE:
+		return p.parseWhileStatement()
+	case token.FOR:
+		return p.parseForStatement()
+	case token.BREAK:
+		return p.parseBreakStatement()
+	case token.CONTINUE:
+		return p.parseContinueStatement()
+	case token.TRY:
+		return p.parseTryStatement()
+	case token.THROW:
+		return p.parseThrowStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -160,6 +204,10 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 
 	stmt.Value = p.parseExpression(LOWEST)
 
+	if fn, ok := stmt.Value.(*ast.FunctionLiteral); ok {
+		fn.Name = stmt.Name.Value
+	}
+
 	if p.check(token.SEMICOLON) {
 		p.advance()
 	}
@@ -184,6 +232,207 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	return stmt
 }
 
+func (p *Parser) parseThrowStatement() *ast.ThrowStatement {
+	defer untrace(trace("parseThrowStatement"))
+	stmt := &ast.ThrowStatement{
+		Token: p.tok,
+	}
+
+	p.advance()
+
+	stmt.Value = p.parseExpression(LOWEST)
+
+	if p.check(token.SEMICOLON) {
+		p.advance()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseTryStatement() *ast.TryStatement {
+	defer untrace(trace("parseTryStatement"))
+	stmt := &ast.TryStatement{
+		Token: p.tok,
+	}
+
+	if !p.expect(token.LBRACE, "expected <{> token following <try>") {
+		return nil
+	}
+
+	stmt.Body = p.parseBlockStatement()
+
+	if p.check(token.CATCH) {
+		p.advance()
+
+		if !p.expect(token.LPAREN, "expected <(> token following <catch>") {
+			return nil
+		}
+
+		if !p.expect(token.IDENT, "expected <IDENT> token following <(>") {
+			return nil
+		}
+
+		stmt.Parameter = &ast.Identifier{
+			Token: p.tok,
+			Value: p.tok.Lexeme,
+		}
+
+		if !p.expect(token.RPAREN, "expected <)> token following catch parameter") {
+			return nil
+		}
+
+		if !p.expect(token.LBRACE, "expected <{> token following <)>") {
+			return nil
+		}
+
+		stmt.Catch = p.parseBlockStatement()
+	}
+
+	if p.check(token.FINALLY) {
+		p.advance()
+
+		if !p.expect(token.LBRACE, "expected <{> token following <finally>") {
+			return nil
+		}
+
+		stmt.Finally = p.parseBlockStatement()
+	}
+
+	if stmt.Catch == nil && stmt.Finally == nil {
+		p.error(p.peek(), "expected <catch> or <finally> token following try block")
+		return nil
+	}
+
+	if p.check(token.SEMICOLON) {
+		p.advance()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseWhileStatement() *ast.WhileStatement {
+	defer untrace(trace("parseWhileStatement"))
+	stmt := &ast.WhileStatement{
+		Token: p.tok,
+	}
+
+	if !p.expect(token.LPAREN, "expected <(> token following <while>") {
+		return nil
+	}
+
+	p.advance()
+
+	stmt.Condition = p.parseExpression(LOWEST)
+
+	if !p.expect(token.RPAREN, "expected <)> token following while condition") {
+		return nil
+	}
+
+	if !p.expect(token.LBRACE, "expected <{> token following <)>") {
+		return nil
+	}
+
+	p.loops = p.loops + 1
+	stmt.Body = p.parseBlockStatement()
+	p.loops = p.loops - 1
+
+	if p.check(token.SEMICOLON) {
+		p.advance()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseForStatement() *ast.ForStatement {
+	defer untrace(trace("parseForStatement"))
+	stmt := &ast.ForStatement{
+		Token: p.tok,
+	}
+
+	if !p.expect(token.LPAREN, "expected <(> token following <for>") {
+		return nil
+	}
+
+	p.advance()
+
+	if p.tok.Type != token.SEMICOLON {
+		stmt.Init = p.parseStatement()
+
+		if p.tok.Type != token.SEMICOLON {
+			p.error(p.peek(), "expected <;> token following for initializer")
+			return nil
+		}
+	}
+
+	if !p.check(token.SEMICOLON) {
+		p.advance()
+
+		stmt.Condition = p.parseExpression(LOWEST)
+	}
+
+	if !p.expect(token.SEMICOLON, "expected <;> token following for condition") {
+		return nil
+	}
+
+	if !p.check(token.RPAREN) {
+		p.advance()
+
+		stmt.Update = p.parseStatement()
+	}
+
+	if !p.expect(token.RPAREN, "expected <)> token following for update") {
+		return nil
+	}
+
+	if !p.expect(token.LBRACE, "expected <{> token following <)>") {
+		return nil
+	}
+
+	p.loops = p.loops + 1
+	stmt.Body = p.parseBlockStatement()
+	p.loops = p.loops - 1
+
+	if p.check(token.SEMICOLON) {
+		p.advance()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseBreakStatement() *ast.BreakStatement {
+	defer untrace(trace("parseBreakStatement"))
+	stmt := &ast.BreakStatement{
+		Token: p.tok,
+	}
+
+	if p.loops == 0 {
+		p.error(p.tok, "unexpected <break> token outside of loop")
+	}
+
+	if p.check(token.SEMICOLON) {
+		p.advance()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseContinueStatement() *ast.ContinueStatement {
+	defer untrace(trace("parseContinueStatement"))
+	stmt := &ast.ContinueStatement{
+		Token: p.tok,
+	}
+
+	if p.loops == 0 {
+		p.error(p.tok, "unexpected <continue> token outside of loop")
+	}
+
+	if p.check(token.SEMICOLON) {
+		p.advance()
+	}
+
+	return stmt
+}
+
 func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 	defer untrace(trace("parseExpressionStatement"))
 	stmt := &ast.ExpressionStatement{
@@ -258,7 +507,34 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 
 	value, err := strconv.ParseInt(p.tok.Lexeme, 0, 64)
 	if err != nil {
-		p.error(p.tok, fmt.Sprintf("ParseInt returned error: %s", err.Error()))
+		if bigValue, ok := new(big.Int).SetString(p.tok.Lexeme, 0); ok {
+			return &ast.BigIntegerLiteral{
+				Token: p.tok,
+				Value: bigValue,
+			}
+		}
+
+		p.error(p.tok, fmt.Sprintf("malformed integer literal <%s>", p.tok.Lexeme))
+		return nil
+	}
+
+	lit.Value = value
+
+	return lit
+}
+
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	defer untrace(trace("parseFloatLiteral"))
+	lit := &ast.FloatLiteral{
+		Token: p.tok,
+	}
+
+	value, err := strconv.ParseFloat(p.tok.Lexeme, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		p.error(p.tok, fmt.Sprintf("float literal <%s> out of range", p.tok.Lexeme))
+		return nil
+	} else if err != nil {
+		p.error(p.tok, fmt.Sprintf("malformed float literal <%s>", p.tok.Lexeme))
 		return nil
 	}
 
@@ -285,46 +561,88 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 		return nil
 	}
 
+	if !p.parseFunctionParameters(lit) {
+		return nil
+	}
+
+	if !p.expect(token.LBRACE, "expected <{> token following <)>") {
+		return nil
+	}
+
+	loops := p.loops
+	p.loops = 0
+	lit.Body = p.parseBlockStatement()
+	p.loops = loops
+
+	return lit
+}
+
+// parseFunctionParameters parses the parameter list following the opening
+// parenthesis, up to and including the closing one. Parameters with a default
+// value must come after those without, and a rest parameter must come last.
+func (p *Parser) parseFunctionParameters(lit *ast.FunctionLiteral) bool {
+	defer untrace(trace("parseFunctionParameters"))
 	idents := []*ast.Identifier{}
+	defaults := []ast.Expression{}
 
 	p.advance()
 
-	if p.tok.Type != token.RPAREN {
-		idents = append(
-			idents,
-			&ast.Identifier{
+	for p.tok.Type != token.RPAREN {
+		if p.tok.Type == token.ELLIPSIS {
+			if !p.expect(token.IDENT, "expected <IDENT> token following <...>") {
+				return false
+			}
+
+			lit.Rest = &ast.Identifier{
 				Token: p.tok,
 				Value: p.tok.Lexeme,
-			},
-		)
+			}
 
-		for p.check(token.COMMA) {
+			if !p.expect(token.RPAREN, "expected <)> token following rest parameter") {
+				return false
+			}
+			break
+		}
+
+		if p.tok.Type != token.IDENT {
+			p.error(p.tok, fmt.Sprintf("expected <IDENT> token for function parameter, found <%s>", p.tok.Type))
+			return false
+		}
+
+		ident := &ast.Identifier{
+			Token: p.tok,
+			Value: p.tok.Lexeme,
+		}
+
+		var def ast.Expression
+		if p.check(token.ASSIGN) {
 			p.advance()
 			p.advance()
 
-			idents = append(
-				idents,
-				&ast.Identifier{
-					Token: p.tok,
-					Value: p.tok.Lexeme,
-				},
-			)
+			def = p.parseExpression(LOWEST)
+		} else if n := len(defaults); n > 0 && defaults[n-1] != nil {
+			p.error(p.tok, fmt.Sprintf("expected default value for parameter <%s> following parameter with default value", ident.Value))
+			return false
 		}
 
-		if !p.expect(token.RPAREN, "expected <)> token following function parameters") {
-			return nil
-		}
-	}
+		idents = append(idents, ident)
+		defaults = append(defaults, def)
 
-	lit.Parameters = idents
+		if !p.check(token.COMMA) {
+			if !p.expect(token.RPAREN, "expected <)> token following function parameters") {
+				return false
+			}
+			break
+		}
 
-	if !p.expect(token.LBRACE, "expected <{> token following <)>") {
-		return nil
+		p.advance()
+		p.advance()
 	}
 
-	lit.Body = p.parseBlockStatement()
+	lit.Parameters = idents
+	lit.Defaults = defaults
 
-	return lit
+	return true
 }
 
 func (p *Parser) parseStringLiteral() ast.Expression {
@@ -335,6 +653,36 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 	}
 }
 
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	defer untrace(trace("parseInterpolatedString"))
+	str := &ast.InterpolatedString{
+		Token: p.tok,
+		Parts: []ast.Expression{},
+	}
+
+	for {
+		if p.tok.Lexeme != "" {
+			str.Parts = append(str.Parts, &ast.StringLiteral{
+				Token: p.tok,
+				Value: p.tok.Lexeme,
+			})
+		}
+
+		if p.tok.Type == token.STRING_TAIL {
+			return str
+		}
+
+		p.advance()
+		str.Parts = append(str.Parts, p.parseExpression(LOWEST))
+
+		if !p.check(token.STRING_MIDDLE) && !p.check(token.STRING_TAIL) {
+			p.error(p.peek(), "expected <}> token following interpolated expression")
+			return nil
+		}
+		p.advance()
+	}
+}
+
 func (p *Parser) parseArrayLiteral() ast.Expression {
 	defer untrace(trace("parseArrayLiteral"))
 	lit := &ast.ArrayLiteral{
@@ -372,7 +720,8 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 		Token: p.tok,
 	}
 
-	pairs := make(map[ast.Expression]ast.Expression)
+	keys := []ast.Expression{}
+	values := []ast.Expression{}
 
 	p.advance()
 
@@ -387,7 +736,8 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 
 		value := p.parseExpression(LOWEST)
 
-		pairs[key] = value
+		keys = append(keys, key)
+		values = append(values, value)
 
 		for p.check(token.COMMA) {
 			p.advance()
@@ -403,7 +753,8 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 
 			value := p.parseExpression(LOWEST)
 
-			pairs[key] = value
+			keys = append(keys, key)
+			values = append(values, value)
 		}
 
 		if !p.expect(token.RBRACE, "expected <}> token following hash pairs") {
@@ -411,7 +762,8 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 		}
 	}
 
-	lit.Pairs = pairs
+	lit.Keys = keys
+	lit.Values = values
 
 	return lit
 
@@ -440,9 +792,33 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	}
 
 	precedence := p.precedence(p.tok.Type)
+	if p.tok.Type == token.POWER {
+		precedence = precedence - 1
+	}
 	p.advance()
 	expr.Right = p.parseExpression(precedence)
-	//                             ^^^ decrement here for right-associativity
+
+	return expr
+}
+
+func (p *Parser) parseAssignExpression(left ast.Expression) ast.Expression {
+	defer untrace(trace("parseAssignExpression"))
+	expr := &ast.AssignExpression{
+		Token:    p.tok,
+		Target:   left,
+		Operator: p.tok.Lexeme,
+	}
+
+	switch left.(type) {
+	case *ast.Identifier, *ast.IndexExpression:
+	default:
+		p.error(p.tok, fmt.Sprintf("invalid assignment target preceding <%s>", p.tok.Type))
+		return nil
+	}
+
+	precedence := p.precedence(p.tok.Type)
+	p.advance()
+	expr.Value = p.parseExpression(precedence - 1)
 
 	return expr
 }
@@ -521,9 +897,15 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 		Struct: left,
 	}
 
-	p.advance()
+	if !p.check(token.COLON) {
+		p.advance()
 
-	expr.Index = p.parseExpression(LOWEST)
+		expr.Index = p.parseExpression(LOWEST)
+	}
+
+	if p.check(token.COLON) {
+		return p.parseSliceExpression(expr.Token, left, expr.Index)
+	}
 
 	if !p.expect(token.RBRACKET, "expected <]> token following <INT>") {
 		return nil
@@ -532,6 +914,29 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	return expr
 }
 
+func (p *Parser) parseSliceExpression(tok token.Token, left ast.Expression, start ast.Expression) ast.Expression {
+	defer untrace(trace("parseSliceExpression"))
+	expr := &ast.SliceExpression{
+		Token:  tok,
+		Struct: left,
+		Start:  start,
+	}
+
+	p.advance()
+
+	if !p.check(token.RBRACKET) {
+		p.advance()
+
+		expr.End = p.parseExpression(LOWEST)
+	}
+
+	if !p.expect(token.RBRACKET, "expected <]> token following slice") {
+		return nil
+	}
+
+	return expr
+}
+
 func (p *Parser) parseMacroExpression() ast.Expression {
 	defer untrace(trace("parseMacroExpression"))
 	lit := &ast.MacroExpression{
@@ -579,7 +984,10 @@ func (p *Parser) parseMacroExpression() ast.Expression {
 		return nil
 	}
 
+	loops := p.loops
+	p.loops = 0
 	lit.Body = p.parseBlockStatement()
+	p.loops = loops
 
 	return lit
 }
@@ -599,13 +1007,17 @@ func (p *Parser) parseGroupedExpression() ast.Expression {
 
 func (p *Parser) advance() {
 	if p.current >= len(p.tokens) {
-		p.tok = token.Token{}
+		p.tok = p.tokens[len(p.tokens)-1]
 	} else {
 		p.tok = p.tokens[p.current]
 		p.current = p.current + 1
 
 		for p.tok.Type == token.ILLEGAL {
-			p.error(p.tok, "illegal token")
+			if msg, ok := p.l.Error(p.tok.Offset); ok {
+				p.error(p.tok, msg)
+			} else {
+				p.error(p.tok, "illegal token")
+			}
 			p.tok = p.tokens[p.current]
 			p.current = p.current + 1
 		}
@@ -614,7 +1026,7 @@ func (p *Parser) advance() {
 
 func (p *Parser) peek() token.Token {
 	if p.current >= len(p.tokens) {
-		return token.Token{}
+		return p.tokens[len(p.tokens)-1]
 	}
 	return p.tokens[p.current]
 }
@@ -641,20 +1053,7 @@ func (p *Parser) precedence(ttype token.TokenType) int {
 }
 
 func (p *Parser) error(tok token.Token, msg string) {
-	input := p.l.Input()
-
-	ln := 1
-	col := 1
-
-	for i := 0; i < tok.Offset; i = i + 1 {
-		if input[i] == '\n' {
-			col = 1
-			ln = ln + 1
-		}
-		col = col + 1
-	}
-
-	msg = fmt.Sprintf("%d:%d: %s", ln, col, msg)
+	msg = fmt.Sprintf("%d:%d: %s", tok.Line, tok.Column, msg)
 	p.errors = append(p.errors, msg)
 }
 
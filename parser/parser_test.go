@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/eugene-whitaker/writing-an-interpreter-in-go/ast"
@@ -37,6 +38,45 @@ type BlockStatementTest struct {
 
 func (bst BlockStatementTest) statement() {}
 
+type WhileStatementTest struct {
+	condition ExpressionTest
+	body      *BlockStatementTest
+}
+
+func (wst WhileStatementTest) statement() {}
+
+type ForStatementTest struct {
+	init      StatementTest
+	condition ExpressionTest
+	update    StatementTest
+	body      *BlockStatementTest
+}
+
+func (fst ForStatementTest) statement() {}
+
+type BreakStatementTest struct{}
+
+func (bst BreakStatementTest) statement() {}
+
+type ContinueStatementTest struct{}
+
+func (cst ContinueStatementTest) statement() {}
+
+type ThrowStatementTest struct {
+	value ExpressionTest
+}
+
+func (tst ThrowStatementTest) statement() {}
+
+type TryStatementTest struct {
+	body      *BlockStatementTest
+	parameter string
+	catch     *BlockStatementTest
+	finally   *BlockStatementTest
+}
+
+func (tst TryStatementTest) statement() {}
+
 type ExpressionTest interface {
 	expression()
 }
@@ -49,12 +89,22 @@ type IntegerLiteralTest int64
 
 func (ilt IntegerLiteralTest) expression() {}
 
+type BigIntegerLiteralTest string
+
+func (bilt BigIntegerLiteralTest) expression() {}
+
+type FloatLiteralTest float64
+
+func (flt FloatLiteralTest) expression() {}
+
 type BooleanLiteralTest bool
 
 func (blt BooleanLiteralTest) expression() {}
 
 type FunctionLiteralTest struct {
 	parameters []string
+	defaults   []ExpressionTest
+	rest       string
 	body       *BlockStatementTest
 }
 
@@ -64,6 +114,10 @@ type StringLiteralTest string
 
 func (slt StringLiteralTest) expression() {}
 
+type InterpolatedStringTest []ExpressionTest
+
+func (ist InterpolatedStringTest) expression() {}
+
 type ArrayLiteralTest []ExpressionTest
 
 func (alt ArrayLiteralTest) expression() {}
@@ -87,6 +141,14 @@ type InfixExpressionTest struct {
 
 func (iet InfixExpressionTest) expression() {}
 
+type AssignExpressionTest struct {
+	target   ExpressionTest
+	operator string
+	value    ExpressionTest
+}
+
+func (aet AssignExpressionTest) expression() {}
+
 type IfExpressionTest struct {
 	condition   ExpressionTest
 	consequence *BlockStatementTest
@@ -109,6 +171,14 @@ type IndexExpressionTest struct {
 
 func (iet IndexExpressionTest) expression() {}
 
+type SliceExpressionTest struct {
+	array ExpressionTest
+	start ExpressionTest
+	end   ExpressionTest
+}
+
+func (set SliceExpressionTest) expression() {}
+
 type MacroExpressionTest struct {
 	parameters []string
 	body       *BlockStatementTest
@@ -596,6 +666,8 @@ func TestParseProgram(t *testing.T) {
 							"x",
 							"y",
 						},
+						nil,
+						"",
 						&BlockStatementTest{
 							[]StatementTest{
 								ExpressionStatementTest{
@@ -618,6 +690,8 @@ func TestParseProgram(t *testing.T) {
 				ExpressionStatementTest{
 					FunctionLiteralTest{
 						[]string{},
+						nil,
+						"",
 						&BlockStatementTest{
 							[]StatementTest{},
 						},
@@ -634,6 +708,8 @@ func TestParseProgram(t *testing.T) {
 						[]string{
 							"x",
 						},
+						nil,
+						"",
 						&BlockStatementTest{
 							[]StatementTest{},
 						},
@@ -652,6 +728,54 @@ func TestParseProgram(t *testing.T) {
 							"y",
 							"z",
 						},
+						nil,
+						"",
+						&BlockStatementTest{
+							[]StatementTest{},
+						},
+					},
+				},
+			},
+		},
+		{
+			"fn(x, y = 10, ...rest) { x + y; };",
+			"fn(x, y = 10, ...rest)(x + y)",
+			[]StatementTest{
+				ExpressionStatementTest{
+					FunctionLiteralTest{
+						[]string{
+							"x",
+							"y",
+						},
+						[]ExpressionTest{
+							nil,
+							IntegerLiteralTest(10),
+						},
+						"rest",
+						&BlockStatementTest{
+							[]StatementTest{
+								ExpressionStatementTest{
+									InfixExpressionTest{
+										IdentifierTest("x"),
+										"+",
+										IdentifierTest("y"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"fn(...args) {};",
+			"fn(...args)",
+			[]StatementTest{
+				ExpressionStatementTest{
+					FunctionLiteralTest{
+						[]string{},
+						nil,
+						"args",
 						&BlockStatementTest{
 							[]StatementTest{},
 						},
@@ -1264,9 +1388,64 @@ func TestParseProgram(t *testing.T) {
 				},
 			},
 		},
+		{
+			"\"tab\\t \\\"quoted\\\" \\u{e9}\";",
+			"\"tab\\t \\\"quoted\\\" \u00e9\"",
+			[]StatementTest{
+				ExpressionStatementTest{
+					StringLiteralTest("tab\t \"quoted\" \u00e9"),
+				},
+			},
+		},
+		{
+			"`multi\nline \\n`;",
+			"\"multi\\nline \\\\n\"",
+			[]StatementTest{
+				ExpressionStatementTest{
+					StringLiteralTest("multi\nline \\n"),
+				},
+			},
+		},
+		{
+			"\"hello ${name}, you are ${age + 1}\";",
+			"\"hello ${name}, you are ${(age + 1)}\"",
+			[]StatementTest{
+				ExpressionStatementTest{
+					InterpolatedStringTest{
+						StringLiteralTest("hello "),
+						IdentifierTest("name"),
+						StringLiteralTest(", you are "),
+						InfixExpressionTest{
+							IdentifierTest("age"),
+							"+",
+							IntegerLiteralTest(1),
+						},
+					},
+				},
+			},
+		},
+		{
+			"\"${[\"${b}\"][0]}\\${c}\";",
+			"\"${([\"${b}\"][0])}\\${c}\"",
+			[]StatementTest{
+				ExpressionStatementTest{
+					InterpolatedStringTest{
+						IndexExpressionTest{
+							ArrayLiteralTest{
+								InterpolatedStringTest{
+									IdentifierTest("b"),
+								},
+							},
+							IntegerLiteralTest(0),
+						},
+						StringLiteralTest("${c}"),
+					},
+				},
+			},
+		},
 		{
 			"\"hello world\";",
-			"hello world",
+			"\"hello world\"",
 			[]StatementTest{
 				ExpressionStatementTest{
 					StringLiteralTest("hello world"),
@@ -1323,7 +1502,7 @@ func TestParseProgram(t *testing.T) {
 		},
 		{
 			"{\"one\": 1, \"two\": 2, \"three\": 3};",
-			"{one:1, two:2, three:3}",
+			"{\"one\":1, \"two\":2, \"three\":3}",
 			[]StatementTest{
 				ExpressionStatementTest{
 					HashLiteralTest{
@@ -1345,7 +1524,7 @@ func TestParseProgram(t *testing.T) {
 		},
 		{
 			"{\"one\": 0 + 1, \"two\": 10 - 8, \"three\": 15 / 5};",
-			"{one:(0 + 1), two:(10 - 8), three:(15 / 5)}",
+			"{\"one\":(0 + 1), \"two\":(10 - 8), \"three\":(15 / 5)}",
 			[]StatementTest{
 				ExpressionStatementTest{
 					HashLiteralTest{
@@ -1418,176 +1597,1069 @@ func TestParseProgram(t *testing.T) {
 				},
 			},
 		},
-	}
-
-	for i, test := range tests {
-		if !testProgram(t, i, test.input, test.precedence, test.tests) {
-			continue
-		}
-	}
-}
-
-func testProgram(t *testing.T, idx int, input string, precedence string, tests []StatementTest) bool {
-	l := lexer.NewLexer(input)
-	p := NewParser(l)
-	program := p.ParseProgram()
-
-	if 0 != len(p.Errors()) {
-		t.Errorf("test[%d] - %q - len(p.Errors()) ==> expected: 0 actual: %d", idx, input, len(p.Errors()))
-		for i, msg := range p.Errors() {
-			t.Errorf("test[%d] - p.Errors()[%d]: %q", idx, i, msg)
-		}
-		t.FailNow()
-	}
-
-	if program == nil {
-		t.Errorf("test[%d] - %q - p.ParseProgram() ==> expected: not <nil>", idx, input)
-		return false
-	}
-
-	actual := program.String()
-	if precedence != actual {
-		t.Logf("test[%d] - %q - program.String() ==> expected: %q actual: %q", idx, input, precedence, actual)
-		return false
-	}
-
-	if len(tests) != len(program.Statements) {
-		t.Errorf("test[%d] - %q - len(program.Statements) ==> expected: %d actual: %d", idx, input, len(tests), len(program.Statements))
-		return false
-	}
-
-	for i, stmt := range program.Statements {
-		if !testStatement(t, idx, input, stmt, tests[i]) {
-			return false
-		}
-	}
-
-	return true
-}
-
-func testStatement(t *testing.T, idx int, input string, stmt ast.Statement, test StatementTest) bool {
-	switch test := test.(type) {
-	case LetStatementTest:
-		return testLetStatement(t, idx, input, stmt, test.name, test.value)
-	case ReturnStatementTest:
-		return testReturnStatement(t, idx, input, stmt, test.returnValue)
-	case ExpressionStatementTest:
-		return testExpressionStatement(t, idx, input, stmt, test.test)
-	case *BlockStatementTest:
-		return testBlockStatement(t, idx, input, stmt, test.tests)
-	}
-	t.Errorf("test[%d] - %q ==> unexpected type. actual: %T", idx, input, test)
-	return false
-}
-
-func testLetStatement(t *testing.T, idx int, input string, stmt ast.Statement, name string, value ExpressionTest) bool {
-	if "let" != stmt.TokenLexeme() {
-		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: 'let' actual: %q", idx, input, stmt.TokenLexeme())
-		return false
-	}
-
-	letStmt, ok := stmt.(*ast.LetStatement)
-	if !ok {
-		t.Errorf("test[%d] - %q - stmt.(*ast.LetStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.LetStatement{}, stmt)
-		return false
-	}
-
-	if !testIdentifier(t, idx, input, letStmt.Name, name) {
-		return false
-	}
-
-	if !testExpression(t, idx, input, letStmt.Value, value) {
-		return false
-	}
-
-	return true
-}
-
-func testReturnStatement(t *testing.T, idx int, input string, stmt ast.Statement, returnValue ExpressionTest) bool {
-	if "return" != stmt.TokenLexeme() {
-		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: 'return' actual: %q", idx, input, stmt.TokenLexeme())
-		return false
-	}
-
-	returnStmt, ok := stmt.(*ast.ReturnStatement)
-	if !ok {
-		t.Errorf("test[%d] - %q - stmt.(*ast.ReturnStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.ReturnStatement{}, stmt)
-		return false
-	}
-
-	if !testExpression(t, idx, input, returnStmt.ReturnValue, returnValue) {
-		return false
-	}
-
-	return true
-}
-
-func testExpressionStatement(t *testing.T, idx int, input string, stmt ast.Statement, test ExpressionTest) bool {
-	exprStmt, ok := stmt.(*ast.ExpressionStatement)
-	if !ok {
-		t.Errorf("test[%d] - %q - stmt.(*ast.ExpressionStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.ExpressionStatement{}, stmt)
-		return false
-	}
-
-	if !testExpression(t, idx, input, exprStmt.Expression, test) {
-		return false
-	}
-
-	return true
-}
-
-func testBlockStatement(t *testing.T, idx int, input string, stmt ast.Statement, tests []StatementTest) bool {
-	if "{" != stmt.TokenLexeme() {
-		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: '{' actual: %q", idx, input, stmt.TokenLexeme())
-		return false
-	}
-
-	blockStmt, ok := stmt.(*ast.BlockStatement)
-	if !ok {
-		t.Errorf("test[%d] - %q - stmt.(*ast.BlockStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.BlockStatement{}, stmt)
-		return false
-	}
-
-	if len(tests) != len(blockStmt.Statements) {
-		t.Errorf("test[%d] - %q - len(blockStmt.Statements) ==> expected: %d actual: %d", idx, input, len(tests), len(blockStmt.Statements))
-		return false
-	}
-
-	for i, stmt := range blockStmt.Statements {
-		if !testStatement(t, idx, input, stmt, tests[i]) {
-			return false
-		}
-	}
-
-	return true
-}
-
-func testExpression(t *testing.T, idx int, input string, exp ast.Expression, test ExpressionTest) bool {
-	switch test := test.(type) {
-	case IdentifierTest:
-		return testIdentifier(t, idx, input, exp, string(test))
+		{
+			"while (x < y) { x; };",
+			"while (x < y) x",
+			[]StatementTest{
+				WhileStatementTest{
+					InfixExpressionTest{
+						IdentifierTest("x"),
+						"<",
+						IdentifierTest("y"),
+					},
+					&BlockStatementTest{
+						[]StatementTest{
+							ExpressionStatementTest{
+								IdentifierTest("x"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"throw \"boom\";",
+			"throw \"boom\";",
+			[]StatementTest{
+				ThrowStatementTest{
+					StringLiteralTest("boom"),
+				},
+			},
+		},
+		{
+			"try { f(); } catch (e) { e; } finally { g(); }",
+			"try f() catch (e) e finally g()",
+			[]StatementTest{
+				TryStatementTest{
+					&BlockStatementTest{
+						[]StatementTest{
+							ExpressionStatementTest{
+								CallExpressionTest{
+									IdentifierTest("f"),
+									[]ExpressionTest{},
+								},
+							},
+						},
+					},
+					"e",
+					&BlockStatementTest{
+						[]StatementTest{
+							ExpressionStatementTest{
+								IdentifierTest("e"),
+							},
+						},
+					},
+					&BlockStatementTest{
+						[]StatementTest{
+							ExpressionStatementTest{
+								CallExpressionTest{
+									IdentifierTest("g"),
+									[]ExpressionTest{},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"try { throw 1; } catch (e) {}",
+			"try throw 1; catch (e) ",
+			[]StatementTest{
+				TryStatementTest{
+					&BlockStatementTest{
+						[]StatementTest{
+							ThrowStatementTest{
+								IntegerLiteralTest(1),
+							},
+						},
+					},
+					"e",
+					&BlockStatementTest{
+						[]StatementTest{},
+					},
+					nil,
+				},
+			},
+		},
+		{
+			"try {} finally { x; };",
+			"try  finally x",
+			[]StatementTest{
+				TryStatementTest{
+					&BlockStatementTest{
+						[]StatementTest{},
+					},
+					"",
+					nil,
+					&BlockStatementTest{
+						[]StatementTest{
+							ExpressionStatementTest{
+								IdentifierTest("x"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"while (true) { break; continue; }",
+			"while true break;continue;",
+			[]StatementTest{
+				WhileStatementTest{
+					BooleanLiteralTest(true),
+					&BlockStatementTest{
+						[]StatementTest{
+							BreakStatementTest{},
+							ContinueStatementTest{},
+						},
+					},
+				},
+			},
+		},
+		{
+			"for (let i = 0; i < 10; let i = i + 1) { i; }",
+			"for (let i = 0; (i < 10); let i = (i + 1)) i",
+			[]StatementTest{
+				ForStatementTest{
+					LetStatementTest{
+						"i",
+						IntegerLiteralTest(0),
+					},
+					InfixExpressionTest{
+						IdentifierTest("i"),
+						"<",
+						IntegerLiteralTest(10),
+					},
+					LetStatementTest{
+						"i",
+						InfixExpressionTest{
+							IdentifierTest("i"),
+							"+",
+							IntegerLiteralTest(1),
+						},
+					},
+					&BlockStatementTest{
+						[]StatementTest{
+							ExpressionStatementTest{
+								IdentifierTest("i"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"for (;;) { break; }",
+			"for (;;) break;",
+			[]StatementTest{
+				ForStatementTest{
+					nil,
+					nil,
+					nil,
+					&BlockStatementTest{
+						[]StatementTest{
+							BreakStatementTest{},
+						},
+					},
+				},
+			},
+		},
+		{
+			"x = 5;",
+			"(x = 5)",
+			[]StatementTest{
+				ExpressionStatementTest{
+					AssignExpressionTest{
+						IdentifierTest("x"),
+						"=",
+						IntegerLiteralTest(5),
+					},
+				},
+			},
+		},
+		{
+			"x = y = 1 + 2;",
+			"(x = (y = (1 + 2)))",
+			[]StatementTest{
+				ExpressionStatementTest{
+					AssignExpressionTest{
+						IdentifierTest("x"),
+						"=",
+						AssignExpressionTest{
+							IdentifierTest("y"),
+							"=",
+							InfixExpressionTest{
+								IntegerLiteralTest(1),
+								"+",
+								IntegerLiteralTest(2),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"x += y * 2;",
+			"(x += (y * 2))",
+			[]StatementTest{
+				ExpressionStatementTest{
+					AssignExpressionTest{
+						IdentifierTest("x"),
+						"+=",
+						InfixExpressionTest{
+							IdentifierTest("y"),
+							"*",
+							IntegerLiteralTest(2),
+						},
+					},
+				},
+			},
+		},
+		{
+			"x -= 1; x *= 2; x /= 3;",
+			"(x -= 1)(x *= 2)(x /= 3)",
+			[]StatementTest{
+				ExpressionStatementTest{
+					AssignExpressionTest{
+						IdentifierTest("x"),
+						"-=",
+						IntegerLiteralTest(1),
+					},
+				},
+				ExpressionStatementTest{
+					AssignExpressionTest{
+						IdentifierTest("x"),
+						"*=",
+						IntegerLiteralTest(2),
+					},
+				},
+				ExpressionStatementTest{
+					AssignExpressionTest{
+						IdentifierTest("x"),
+						"/=",
+						IntegerLiteralTest(3),
+					},
+				},
+			},
+		},
+		{
+			"array[1] = 2;",
+			"((array[1]) = 2)",
+			[]StatementTest{
+				ExpressionStatementTest{
+					AssignExpressionTest{
+						IndexExpressionTest{
+							IdentifierTest("array"),
+							IntegerLiteralTest(1),
+						},
+						"=",
+						IntegerLiteralTest(2),
+					},
+				},
+			},
+		},
+		{
+			"hash[\"key\"] += 1;",
+			"((hash[\"key\"]) += 1)",
+			[]StatementTest{
+				ExpressionStatementTest{
+					AssignExpressionTest{
+						IndexExpressionTest{
+							IdentifierTest("hash"),
+							StringLiteralTest("key"),
+						},
+						"+=",
+						IntegerLiteralTest(1),
+					},
+				},
+			},
+		},
+		{
+			"a || b && c == d;",
+			"(a || (b && (c == d)))",
+			[]StatementTest{
+				ExpressionStatementTest{
+					InfixExpressionTest{
+						IdentifierTest("a"),
+						"||",
+						InfixExpressionTest{
+							IdentifierTest("b"),
+							"&&",
+							InfixExpressionTest{
+								IdentifierTest("c"),
+								"==",
+								IdentifierTest("d"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"x = a && b || c;",
+			"(x = ((a && b) || c))",
+			[]StatementTest{
+				ExpressionStatementTest{
+					AssignExpressionTest{
+						IdentifierTest("x"),
+						"=",
+						InfixExpressionTest{
+							InfixExpressionTest{
+								IdentifierTest("a"),
+								"&&",
+								IdentifierTest("b"),
+							},
+							"||",
+							IdentifierTest("c"),
+						},
+					},
+				},
+			},
+		},
+		{
+			"a <= b == c >= d;",
+			"((a <= b) == (c >= d))",
+			[]StatementTest{
+				ExpressionStatementTest{
+					InfixExpressionTest{
+						InfixExpressionTest{
+							IdentifierTest("a"),
+							"<=",
+							IdentifierTest("b"),
+						},
+						"==",
+						InfixExpressionTest{
+							IdentifierTest("c"),
+							">=",
+							IdentifierTest("d"),
+						},
+					},
+				},
+			},
+		},
+		{
+			"a + b % c;",
+			"(a + (b % c))",
+			[]StatementTest{
+				ExpressionStatementTest{
+					InfixExpressionTest{
+						IdentifierTest("a"),
+						"+",
+						InfixExpressionTest{
+							IdentifierTest("b"),
+							"%",
+							IdentifierTest("c"),
+						},
+					},
+				},
+			},
+		},
+		{
+			"a ** b ** c;",
+			"(a ** (b ** c))",
+			[]StatementTest{
+				ExpressionStatementTest{
+					InfixExpressionTest{
+						IdentifierTest("a"),
+						"**",
+						InfixExpressionTest{
+							IdentifierTest("b"),
+							"**",
+							IdentifierTest("c"),
+						},
+					},
+				},
+			},
+		},
+		{
+			"-a ** b * c;",
+			"((-(a ** b)) * c)",
+			[]StatementTest{
+				ExpressionStatementTest{
+					InfixExpressionTest{
+						PrefixExpressionTest{
+							"-",
+							InfixExpressionTest{
+								IdentifierTest("a"),
+								"**",
+								IdentifierTest("b"),
+							},
+						},
+						"*",
+						IdentifierTest("c"),
+					},
+				},
+			},
+		},
+		{
+			"3.14 * 1e-3;",
+			"(3.14 * 1e-3)",
+			[]StatementTest{
+				ExpressionStatementTest{
+					InfixExpressionTest{
+						FloatLiteralTest(3.14),
+						"*",
+						FloatLiteralTest(0.001),
+					},
+				},
+			},
+		},
+		{
+			"[0xff, 0o17, 0b101, 1_000_000, 1_000.5];",
+			"[0xff, 0o17, 0b101, 1_000_000, 1_000.5]",
+			[]StatementTest{
+				ExpressionStatementTest{
+					ArrayLiteralTest{
+						IntegerLiteralTest(255),
+						IntegerLiteralTest(15),
+						IntegerLiteralTest(5),
+						IntegerLiteralTest(1000000),
+						FloatLiteralTest(1000.5),
+					},
+				},
+			},
+		},
+		{
+			"a[1:b + 1];",
+			"(a[1:(b + 1)])",
+			[]StatementTest{
+				ExpressionStatementTest{
+					SliceExpressionTest{
+						IdentifierTest("a"),
+						IntegerLiteralTest(1),
+						InfixExpressionTest{
+							IdentifierTest("b"),
+							"+",
+							IntegerLiteralTest(1),
+						},
+					},
+				},
+			},
+		},
+		{
+			"a[-2:][:1];",
+			"((a[(-2):])[:1])",
+			[]StatementTest{
+				ExpressionStatementTest{
+					SliceExpressionTest{
+						SliceExpressionTest{
+							IdentifierTest("a"),
+							PrefixExpressionTest{
+								"-",
+								IntegerLiteralTest(2),
+							},
+							nil,
+						},
+						nil,
+						IntegerLiteralTest(1),
+					},
+				},
+			},
+		},
+		{
+			"a[:];",
+			"(a[:])",
+			[]StatementTest{
+				ExpressionStatementTest{
+					SliceExpressionTest{
+						IdentifierTest("a"),
+						nil,
+						nil,
+					},
+				},
+			},
+		},
+		{
+			"-18446744073709551616;",
+			"(-18446744073709551616)",
+			[]StatementTest{
+				ExpressionStatementTest{
+					PrefixExpressionTest{
+						"-",
+						BigIntegerLiteralTest("18446744073709551616"),
+					},
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		if !testProgram(t, i, test.input, test.precedence, test.tests) {
+			continue
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		errors []string
+	}{
+		{
+			"break;",
+			[]string{
+				"1:1: unexpected <break> token outside of loop",
+			},
+		},
+		{
+			"while (true) { fn() { continue; }; }",
+			[]string{
+				"1:23: unexpected <continue> token outside of loop",
+			},
+		},
+		{
+			"for (let i = 0 i) {}",
+			[]string{
+				"1:16: expected <;> token following for initializer",
+				"1:17: no prefix parse function for <)>",
+			},
+		},
+		{
+			"1 = 2;",
+			[]string{
+				"1:3: invalid assignment target preceding <=>",
+			},
+		},
+		{
+			"f() += 2;",
+			[]string{
+				"1:5: invalid assignment target preceding <+=>",
+			},
+		},
+		{
+			"let s = \"a\\qb\";",
+			[]string{
+				"1:11: invalid escape sequence <\\q> in string",
+				"1:15: no prefix parse function for <;>",
+			},
+		},
+		{
+			"let s = \"abc",
+			[]string{
+				"1:9: unterminated string",
+				"1:13: no prefix parse function for <>",
+			},
+		},
+		{
+			"let x = 1e+;",
+			[]string{
+				"1:9: malformed exponent",
+				"1:12: no prefix parse function for <;>",
+			},
+		},
+		{
+			"\"a ${b c}\";",
+			[]string{
+				"1:8: expected <}> token following interpolated expression",
+				"1:9: no prefix parse function for <STRING_TAIL>",
+			},
+		},
+		{
+			"let x = 1;\n/* never /* closed */\nlet y = 2;",
+			[]string{
+				"2:1: unterminated block comment",
+			},
+		},
+		{
+			"let x = 0x1G;",
+			[]string{
+				"1:9: malformed integer literal <0x1G>",
+			},
+		},
+		{
+			"[1__0, 0b, 2_];",
+			[]string{
+				"1:2: malformed integer literal <1__0>",
+				"1:8: malformed integer literal <0b>",
+				"1:12: malformed integer literal <2_>",
+			},
+		},
+		{
+			"let y =\n  1e400;",
+			[]string{
+				"2:3: float literal <1e400> out of range",
+			},
+		},
+		{
+			"try { x; }",
+			[]string{
+				"1:11: expected <catch> or <finally> token following try block",
+			},
+		},
+		{
+			"try { x; } catch e { e; }",
+			[]string{
+				"1:18: expected <(> token following <catch>",
+				"1:23: expected <:> token following hash key",
+				"1:25: no prefix parse function for <}>",
+			},
+		},
+		{
+			"try { x; } catch () {}",
+			[]string{
+				"1:19: expected <IDENT> token following <(>",
+				"1:19: no prefix parse function for <)>",
+			},
+		},
+		{
+			"try x;",
+			[]string{
+				"1:5: expected <{> token following <try>",
+			},
+		},
+		{
+			"try {} finally x;",
+			[]string{
+				"1:16: expected <{> token following <finally>",
+			},
+		},
+		{
+			"fn(x = 1, y) {};",
+			[]string{
+				"1:11: expected default value for parameter <y> following parameter with default value",
+				"1:12: no prefix parse function for <)>",
+			},
+		},
+		{
+			"fn(1, \"s\" = 2) {};",
+			[]string{
+				"1:4: expected <IDENT> token for function parameter, found <INT>",
+				"1:5: no prefix parse function for <,>",
+				"1:11: invalid assignment target preceding <=>",
+				"1:14: no prefix parse function for <)>",
+			},
+		},
+		{
+			"fn(x, \"s\" = 2) {};",
+			[]string{
+				"1:7: expected <IDENT> token for function parameter, found <STRING>",
+				"1:11: invalid assignment target preceding <=>",
+				"1:14: no prefix parse function for <)>",
+			},
+		},
+		{
+			"[1,\n  2,",
+			[]string{
+				"2:5: no prefix parse function for <>",
+				"2:5: expected <]> token following array elements",
+			},
+		},
+		{
+			"{\"a\":\n  1, \"b\"",
+			[]string{
+				"2:9: expected <:> token following hash key",
+			},
+		},
+		{
+			"fn(...rest, x) {};",
+			[]string{
+				"1:11: expected <)> token following rest parameter",
+				"1:11: no prefix parse function for <,>",
+				"1:14: no prefix parse function for <)>",
+			},
+		},
+		{
+			"fn(...) {};",
+			[]string{
+				"1:7: expected <IDENT> token following <...>",
+				"1:7: no prefix parse function for <)>",
+			},
+		},
+		{
+			"a[1:2:3];",
+			[]string{
+				"1:6: expected <]> token following slice",
+				"1:6: no prefix parse function for <:>",
+				"1:8: no prefix parse function for <]>",
+			},
+		},
+	}
+
+	for i, test := range tests {
+		if !testErrors(t, i, test.input, test.errors) {
+			continue
+		}
+	}
+}
+
+func testProgram(t *testing.T, idx int, input string, precedence string, tests []StatementTest) bool {
+	l := lexer.NewLexer(input)
+	p := NewParser(l)
+	program := p.ParseProgram()
+
+	if 0 != len(p.Errors()) {
+		t.Errorf("test[%d] - %q - len(p.Errors()) ==> expected: 0 actual: %d", idx, input, len(p.Errors()))
+		for i, msg := range p.Errors() {
+			t.Errorf("test[%d] - p.Errors()[%d]: %q", idx, i, msg)
+		}
+		t.FailNow()
+	}
+
+	if program == nil {
+		t.Errorf("test[%d] - %q - p.ParseProgram() ==> expected: not <nil>", idx, input)
+		return false
+	}
+
+	actual := program.String()
+	if precedence != actual {
+		t.Logf("test[%d] - %q - program.String() ==> expected: %q actual: %q", idx, input, precedence, actual)
+		return false
+	}
+
+	if len(tests) != len(program.Statements) {
+		t.Errorf("test[%d] - %q - len(program.Statements) ==> expected: %d actual: %d", idx, input, len(tests), len(program.Statements))
+		return false
+	}
+
+	for i, stmt := range program.Statements {
+		if !testStatement(t, idx, input, stmt, tests[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func testErrors(t *testing.T, idx int, input string, errors []string) bool {
+	l := lexer.NewLexer(input)
+	p := NewParser(l)
+	p.ParseProgram()
+
+	if len(errors) != len(p.Errors()) {
+		t.Errorf("test[%d] - %q - len(p.Errors()) ==> expected: %d actual: %d", idx, input, len(errors), len(p.Errors()))
+		for i, msg := range p.Errors() {
+			t.Errorf("test[%d] - p.Errors()[%d]: %q", idx, i, msg)
+		}
+		return false
+	}
+
+	for i, msg := range p.Errors() {
+		if errors[i] != msg {
+			t.Errorf("test[%d] - %q - p.Errors()[%d] ==> expected: %q actual: %q", idx, input, i, errors[i], msg)
+			return false
+		}
+	}
+
+	return true
+}
+
+func testStatement(t *testing.T, idx int, input string, stmt ast.Statement, test StatementTest) bool {
+	switch test := test.(type) {
+	case LetStatementTest:
+		return testLetStatement(t, idx, input, stmt, test.name, test.value)
+	case ReturnStatementTest:
+		return testReturnStatement(t, idx, input, stmt, test.returnValue)
+	case ExpressionStatementTest:
+		return testExpressionStatement(t, idx, input, stmt, test.test)
+	case *BlockStatementTest:
+		return testBlockStatement(t, idx, input, stmt, test.tests)
+	case WhileStatementTest:
+		return testWhileStatement(t, idx, input, stmt, test.condition, test.body)
+	case ForStatementTest:
+		return testForStatement(t, idx, input, stmt, test.init, test.condition, test.update, test.body)
+	case BreakStatementTest:
+		return testBreakStatement(t, idx, input, stmt)
+	case ContinueStatementTest:
+		return testContinueStatement(t, idx, input, stmt)
+	case ThrowStatementTest:
+		return testThrowStatement(t, idx, input, stmt, test.value)
+	case TryStatementTest:
+		return testTryStatement(t, idx, input, stmt, test.body, test.parameter, test.catch, test.finally)
+	}
+	t.Errorf("test[%d] - %q ==> unexpected type. actual: %T", idx, input, test)
+	return false
+}
+
+func testLetStatement(t *testing.T, idx int, input string, stmt ast.Statement, name string, value ExpressionTest) bool {
+	if "let" != stmt.TokenLexeme() {
+		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: 'let' actual: %q", idx, input, stmt.TokenLexeme())
+		return false
+	}
+
+	letStmt, ok := stmt.(*ast.LetStatement)
+	if !ok {
+		t.Errorf("test[%d] - %q - stmt.(*ast.LetStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.LetStatement{}, stmt)
+		return false
+	}
+
+	if !testIdentifier(t, idx, input, letStmt.Name, name) {
+		return false
+	}
+
+	if !testExpression(t, idx, input, letStmt.Value, value) {
+		return false
+	}
+
+	return true
+}
+
+func testReturnStatement(t *testing.T, idx int, input string, stmt ast.Statement, returnValue ExpressionTest) bool {
+	if "return" != stmt.TokenLexeme() {
+		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: 'return' actual: %q", idx, input, stmt.TokenLexeme())
+		return false
+	}
+
+	returnStmt, ok := stmt.(*ast.ReturnStatement)
+	if !ok {
+		t.Errorf("test[%d] - %q - stmt.(*ast.ReturnStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.ReturnStatement{}, stmt)
+		return false
+	}
+
+	if !testExpression(t, idx, input, returnStmt.ReturnValue, returnValue) {
+		return false
+	}
+
+	return true
+}
+
+func testThrowStatement(t *testing.T, idx int, input string, stmt ast.Statement, value ExpressionTest) bool {
+	if "throw" != stmt.TokenLexeme() {
+		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: 'throw' actual: %q", idx, input, stmt.TokenLexeme())
+		return false
+	}
+
+	throwStmt, ok := stmt.(*ast.ThrowStatement)
+	if !ok {
+		t.Errorf("test[%d] - %q - stmt.(*ast.ThrowStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.ThrowStatement{}, stmt)
+		return false
+	}
+
+	if !testExpression(t, idx, input, throwStmt.Value, value) {
+		return false
+	}
+
+	return true
+}
+
+func testTryStatement(t *testing.T, idx int, input string, stmt ast.Statement, body *BlockStatementTest, parameter string, catch *BlockStatementTest, finally *BlockStatementTest) bool {
+	if "try" != stmt.TokenLexeme() {
+		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: 'try' actual: %q", idx, input, stmt.TokenLexeme())
+		return false
+	}
+
+	tryStmt, ok := stmt.(*ast.TryStatement)
+	if !ok {
+		t.Errorf("test[%d] - %q - stmt.(*ast.TryStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.TryStatement{}, stmt)
+		return false
+	}
+
+	if !testBlockStatement(t, idx, input, tryStmt.Body, body.tests) {
+		return false
+	}
+
+	if catch == nil && tryStmt.Catch != nil {
+		t.Errorf("test[%d] - %q - tryStmt.Catch ==> expected: <nil> actual: %q", idx, input, tryStmt.Catch.String())
+		return false
+	}
+
+	if catch != nil {
+		if tryStmt.Catch == nil {
+			t.Errorf("test[%d] - %q - tryStmt.Catch ==> expected: not <nil> actual: <nil>", idx, input)
+			return false
+		}
+
+		if !testIdentifier(t, idx, input, tryStmt.Parameter, parameter) {
+			return false
+		}
+
+		if !testBlockStatement(t, idx, input, tryStmt.Catch, catch.tests) {
+			return false
+		}
+	}
+
+	if finally == nil && tryStmt.Finally != nil {
+		t.Errorf("test[%d] - %q - tryStmt.Finally ==> expected: <nil> actual: %q", idx, input, tryStmt.Finally.String())
+		return false
+	}
+
+	if finally != nil {
+		if tryStmt.Finally == nil {
+			t.Errorf("test[%d] - %q - tryStmt.Finally ==> expected: not <nil> actual: <nil>", idx, input)
+			return false
+		}
+
+		if !testBlockStatement(t, idx, input, tryStmt.Finally, finally.tests) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func testExpressionStatement(t *testing.T, idx int, input string, stmt ast.Statement, test ExpressionTest) bool {
+	exprStmt, ok := stmt.(*ast.ExpressionStatement)
+	if !ok {
+		t.Errorf("test[%d] - %q - stmt.(*ast.ExpressionStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.ExpressionStatement{}, stmt)
+		return false
+	}
+
+	if !testExpression(t, idx, input, exprStmt.Expression, test) {
+		return false
+	}
+
+	return true
+}
+
+func testBlockStatement(t *testing.T, idx int, input string, stmt ast.Statement, tests []StatementTest) bool {
+	if "{" != stmt.TokenLexeme() {
+		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: '{' actual: %q", idx, input, stmt.TokenLexeme())
+		return false
+	}
+
+	blockStmt, ok := stmt.(*ast.BlockStatement)
+	if !ok {
+		t.Errorf("test[%d] - %q - stmt.(*ast.BlockStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.BlockStatement{}, stmt)
+		return false
+	}
+
+	if len(tests) != len(blockStmt.Statements) {
+		t.Errorf("test[%d] - %q - len(blockStmt.Statements) ==> expected: %d actual: %d", idx, input, len(tests), len(blockStmt.Statements))
+		return false
+	}
+
+	for i, stmt := range blockStmt.Statements {
+		if !testStatement(t, idx, input, stmt, tests[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func testWhileStatement(t *testing.T, idx int, input string, stmt ast.Statement, condition ExpressionTest, body *BlockStatementTest) bool {
+	if "while" != stmt.TokenLexeme() {
+		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: 'while' actual: %q", idx, input, stmt.TokenLexeme())
+		return false
+	}
+
+	whileStmt, ok := stmt.(*ast.WhileStatement)
+	if !ok {
+		t.Errorf("test[%d] - %q - stmt.(*ast.WhileStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.WhileStatement{}, stmt)
+		return false
+	}
+
+	if !testExpression(t, idx, input, whileStmt.Condition, condition) {
+		return false
+	}
+
+	if !testBlockStatement(t, idx, input, whileStmt.Body, body.tests) {
+		return false
+	}
+
+	return true
+}
+
+func testForStatement(t *testing.T, idx int, input string, stmt ast.Statement, init StatementTest, condition ExpressionTest, update StatementTest, body *BlockStatementTest) bool {
+	if "for" != stmt.TokenLexeme() {
+		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: 'for' actual: %q", idx, input, stmt.TokenLexeme())
+		return false
+	}
+
+	forStmt, ok := stmt.(*ast.ForStatement)
+	if !ok {
+		t.Errorf("test[%d] - %q - stmt.(*ast.ForStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.ForStatement{}, stmt)
+		return false
+	}
+
+	if init == nil && forStmt.Init != nil {
+		t.Errorf("test[%d] - %q - forStmt.Init ==> expected: <nil> actual: %T", idx, input, forStmt.Init)
+		return false
+	}
+
+	if init != nil && !testStatement(t, idx, input, forStmt.Init, init) {
+		return false
+	}
+
+	if condition == nil && forStmt.Condition != nil {
+		t.Errorf("test[%d] - %q - forStmt.Condition ==> expected: <nil> actual: %T", idx, input, forStmt.Condition)
+		return false
+	}
+
+	if condition != nil && !testExpression(t, idx, input, forStmt.Condition, condition) {
+		return false
+	}
+
+	if update == nil && forStmt.Update != nil {
+		t.Errorf("test[%d] - %q - forStmt.Update ==> expected: <nil> actual: %T", idx, input, forStmt.Update)
+		return false
+	}
+
+	if update != nil && !testStatement(t, idx, input, forStmt.Update, update) {
+		return false
+	}
+
+	if !testBlockStatement(t, idx, input, forStmt.Body, body.tests) {
+		return false
+	}
+
+	return true
+}
+
+func testBreakStatement(t *testing.T, idx int, input string, stmt ast.Statement) bool {
+	if "break" != stmt.TokenLexeme() {
+		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: 'break' actual: %q", idx, input, stmt.TokenLexeme())
+		return false
+	}
+
+	if _, ok := stmt.(*ast.BreakStatement); !ok {
+		t.Errorf("test[%d] - %q - stmt.(*ast.BreakStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.BreakStatement{}, stmt)
+		return false
+	}
+
+	return true
+}
+
+func testContinueStatement(t *testing.T, idx int, input string, stmt ast.Statement) bool {
+	if "continue" != stmt.TokenLexeme() {
+		t.Errorf("test[%d] - %q - stmt.TokenLexeme() ==> expected: 'continue' actual: %q", idx, input, stmt.TokenLexeme())
+		return false
+	}
+
+	if _, ok := stmt.(*ast.ContinueStatement); !ok {
+		t.Errorf("test[%d] - %q - stmt.(*ast.ContinueStatement) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.ContinueStatement{}, stmt)
+		return false
+	}
+
+	return true
+}
+
+func testExpression(t *testing.T, idx int, input string, exp ast.Expression, test ExpressionTest) bool {
+	switch test := test.(type) {
+	case IdentifierTest:
+		return testIdentifier(t, idx, input, exp, string(test))
 	case IntegerLiteralTest:
 		return testIntegerLiteral(t, idx, input, exp, int64(test))
+	case BigIntegerLiteralTest:
+		return testBigIntegerLiteral(t, idx, input, exp, string(test))
+	case FloatLiteralTest:
+		return testFloatLiteral(t, idx, input, exp, float64(test))
 	case BooleanLiteralTest:
 		return testBooleanLiteral(t, idx, input, exp, bool(test))
 	case FunctionLiteralTest:
-		return testFunctionLiteral(t, idx, input, exp, test.parameters, test.body)
+		return testFunctionLiteral(t, idx, input, exp, test.parameters, test.defaults, test.rest, test.body)
 	case StringLiteralTest:
 		return testStringLiteral(t, idx, input, exp, string(test))
 	case ArrayLiteralTest:
 		return testArrayLiteral(t, idx, input, exp, []ExpressionTest(test))
+	case InterpolatedStringTest:
+		return testInterpolatedString(t, idx, input, exp, []ExpressionTest(test))
 	case HashLiteralTest:
 		return testHashLiteral(t, idx, input, exp, map[any]ExpressionTest(test))
 	case PrefixExpressionTest:
 		return testPrefixExpression(t, idx, input, exp, test.operator, test.rightValue)
 	case InfixExpressionTest:
 		return testInfixExpression(t, idx, input, exp, test.leftValue, test.operator, test.rightValue)
+	case AssignExpressionTest:
+		return testAssignExpression(t, idx, input, exp, test.target, test.operator, test.value)
 	case IfExpressionTest:
 		return testIfExpression(t, idx, input, exp, test.condition, test.consequence, test.alternative)
 	case CallExpressionTest:
 		return testCallExpression(t, idx, input, exp, test.function, test.arguments)
 	case IndexExpressionTest:
 		return testIndexExpression(t, idx, input, exp, test.array, test.index)
+	case SliceExpressionTest:
+		return testSliceExpression(t, idx, input, exp, test.array, test.start, test.end)
 	case MacroExpressionTest:
 		return testMacroExpression(t, idx, input, exp, test.parameters, test.body)
 	}
@@ -1627,7 +2699,7 @@ func testIntegerLiteral(t *testing.T, idx int, input string, expr ast.Expression
 		return false
 	}
 
-	if fmt.Sprintf("%d", value) != integ.TokenLexeme() {
+	if lexeme, err := strconv.ParseInt(integ.TokenLexeme(), 0, 64); err != nil || value != lexeme {
 		t.Errorf("test[%d] - %q - integ.TokenLexeme() ==> expected: %d actual: %q", idx, input, value, integ.TokenLexeme())
 		return false
 	}
@@ -1635,6 +2707,41 @@ func testIntegerLiteral(t *testing.T, idx int, input string, expr ast.Expression
 	return true
 }
 
+func testBigIntegerLiteral(t *testing.T, idx int, input string, expr ast.Expression, value string) bool {
+	integ, ok := expr.(*ast.BigIntegerLiteral)
+	if !ok {
+		t.Errorf("test[%d] - %q - exp.(*ast.BigIntegerLiteral) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.BigIntegerLiteral{}, expr)
+		return false
+	}
+
+	if value != integ.Value.String() {
+		t.Errorf("test[%d] - %q - integ.Value ==> expected: %s actual: %s", idx, input, value, integ.Value.String())
+		return false
+	}
+
+	if value != integ.TokenLexeme() {
+		t.Errorf("test[%d] - %q - integ.TokenLexeme() ==> expected: %s actual: %q", idx, input, value, integ.TokenLexeme())
+		return false
+	}
+
+	return true
+}
+
+func testFloatLiteral(t *testing.T, idx int, input string, expr ast.Expression, value float64) bool {
+	float, ok := expr.(*ast.FloatLiteral)
+	if !ok {
+		t.Errorf("test[%d] - %q - exp.(*ast.FloatLiteral) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.FloatLiteral{}, expr)
+		return false
+	}
+
+	if value != float.Value {
+		t.Errorf("test[%d] - %q - float.Value ==> expected: %g actual: %g", idx, input, value, float.Value)
+		return false
+	}
+
+	return true
+}
+
 func testBooleanLiteral(t *testing.T, idx int, input string, expr ast.Expression, value bool) bool {
 	boolean, ok := expr.(*ast.BooleanLiteral)
 	if !ok {
@@ -1655,7 +2762,7 @@ func testBooleanLiteral(t *testing.T, idx int, input string, expr ast.Expression
 	return true
 }
 
-func testFunctionLiteral(t *testing.T, idx int, input string, expr ast.Expression, params []string, body *BlockStatementTest) bool {
+func testFunctionLiteral(t *testing.T, idx int, input string, expr ast.Expression, params []string, defaults []ExpressionTest, rest string, body *BlockStatementTest) bool {
 	if "fn" != expr.TokenLexeme() {
 		t.Errorf("test[%d] - %q - exp.TokenLexeme() ==> expected: 'fn' actual: %q", idx, input, expr.TokenLexeme())
 		return false
@@ -1678,6 +2785,37 @@ func testFunctionLiteral(t *testing.T, idx int, input string, expr ast.Expressio
 		}
 	}
 
+	if len(fn.Parameters) != len(fn.Defaults) {
+		t.Errorf("test[%d] - %q - len(fn.Defaults) ==> expected: %d actual: %d", idx, input, len(fn.Parameters), len(fn.Defaults))
+		return false
+	}
+
+	for i, def := range fn.Defaults {
+		if i >= len(defaults) || defaults[i] == nil {
+			if def != nil {
+				t.Errorf("test[%d] - %q - fn.Defaults[%d] ==> expected: <nil> actual: %q", idx, input, i, def.String())
+				return false
+			}
+			continue
+		}
+
+		if !testExpression(t, idx, input, def, defaults[i]) {
+			return false
+		}
+	}
+
+	if rest == "" {
+		if fn.Rest != nil {
+			t.Errorf("test[%d] - %q - fn.Rest ==> expected: <nil> actual: %q", idx, input, fn.Rest.String())
+			return false
+		}
+	} else if fn.Rest == nil {
+		t.Errorf("test[%d] - %q - fn.Rest ==> expected: %q actual: <nil>", idx, input, rest)
+		return false
+	} else if !testIdentifier(t, idx, input, fn.Rest, rest) {
+		return false
+	}
+
 	if !testBlockStatement(t, idx, input, fn.Body, body.tests) {
 		return false
 	}
@@ -1705,6 +2843,27 @@ func testStringLiteral(t *testing.T, idx int, input string, expr ast.Expression,
 	return true
 }
 
+func testInterpolatedString(t *testing.T, idx int, input string, expr ast.Expression, tests []ExpressionTest) bool {
+	str, ok := expr.(*ast.InterpolatedString)
+	if !ok {
+		t.Errorf("test[%d] - %q - exp.(*ast.InterpolatedString) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.InterpolatedString{}, expr)
+		return false
+	}
+
+	if len(tests) != len(str.Parts) {
+		t.Errorf("test[%d] - %q - len(str.Parts) ==> expected: %d actual: %d", idx, input, len(tests), len(str.Parts))
+		return false
+	}
+
+	for i, part := range str.Parts {
+		if !testExpression(t, idx, input, part, tests[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func testArrayLiteral(t *testing.T, idx int, input string, expr ast.Expression, tests []ExpressionTest) bool {
 	arr, ok := expr.(*ast.ArrayLiteral)
 	if !ok {
@@ -1733,12 +2892,18 @@ func testHashLiteral(t *testing.T, idx int, input string, expr ast.Expression, t
 		return false
 	}
 
-	if len(tests) != len(hash.Pairs) {
-		t.Errorf("test[%d] - %q - len(hash.Pairs) ==> expected: %d actual: %d", idx, input, len(tests), len(hash.Pairs))
+	if len(tests) != len(hash.Keys) {
+		t.Errorf("test[%d] - %q - len(hash.Keys) ==> expected: %d actual: %d", idx, input, len(tests), len(hash.Keys))
+		return false
+	}
+
+	if len(hash.Keys) != len(hash.Values) {
+		t.Errorf("test[%d] - %q - len(hash.Values) ==> expected: %d actual: %d", idx, input, len(hash.Keys), len(hash.Values))
 		return false
 	}
 
-	for key, value := range hash.Pairs {
+	for i, key := range hash.Keys {
+		value := hash.Values[i]
 		switch key := key.(type) {
 		case *ast.IntegerLiteral:
 			expected, ok := tests[int(key.Value)]
@@ -1818,6 +2983,29 @@ func testInfixExpression(t *testing.T, idx int, input string, expr ast.Expressio
 	return true
 }
 
+func testAssignExpression(t *testing.T, idx int, input string, expr ast.Expression, target ExpressionTest, operator string, value ExpressionTest) bool {
+	assignExpr, ok := expr.(*ast.AssignExpression)
+	if !ok {
+		t.Errorf("test[%d] - %q - exp.(*ast.AssignExpression) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.AssignExpression{}, expr)
+		return false
+	}
+
+	if !testExpression(t, idx, input, assignExpr.Target, target) {
+		return false
+	}
+
+	if operator != assignExpr.Operator {
+		t.Errorf("test[%d] - %q - assignExpr.Operator ==> expected: %q actual: %q", idx, input, operator, assignExpr.Operator)
+		return false
+	}
+
+	if !testExpression(t, idx, input, assignExpr.Value, value) {
+		return false
+	}
+
+	return true
+}
+
 func testIfExpression(t *testing.T, idx int, input string, expr ast.Expression, condition ExpressionTest, consequence *BlockStatementTest, alternative *BlockStatementTest) bool {
 	if "if" != expr.TokenLexeme() {
 		t.Errorf("test[%d] - %q - exp.TokenLexeme() ==> expected: 'if' actual: %q", idx, input, expr.TokenLexeme())
@@ -1863,7 +3051,7 @@ func testCallExpression(t *testing.T, idx int, input string, expr ast.Expression
 			return false
 		}
 	case FunctionLiteralTest:
-		if !testFunctionLiteral(t, idx, input, callExpr.Function, test.parameters, test.body) {
+		if !testFunctionLiteral(t, idx, input, callExpr.Function, test.parameters, test.defaults, test.rest, test.body) {
 			return false
 		}
 	default:
@@ -1918,6 +3106,48 @@ func testIndexExpression(t *testing.T, idx int, input string, expr ast.Expressio
 	return true
 }
 
+func testSliceExpression(t *testing.T, idx int, input string, expr ast.Expression, array ExpressionTest, start ExpressionTest, end ExpressionTest) bool {
+	if "[" != expr.TokenLexeme() {
+		t.Errorf("test[%d] - %q - exp.TokenLexeme() ==> expected: '[' actual: %q", idx, input, expr.TokenLexeme())
+		return false
+	}
+
+	sliceExpr, ok := expr.(*ast.SliceExpression)
+	if !ok {
+		t.Errorf("test[%d] - %q - exp.(*ast.SliceExpression) ==> unexpected type. expected: %T actual: %T", idx, input, &ast.SliceExpression{}, expr)
+		return false
+	}
+
+	if !testExpression(t, idx, input, sliceExpr.Struct, array) {
+		return false
+	}
+
+	bounds := []struct {
+		name string
+		expr ast.Expression
+		test ExpressionTest
+	}{
+		{"sliceExpr.Start", sliceExpr.Start, start},
+		{"sliceExpr.End", sliceExpr.End, end},
+	}
+
+	for _, bound := range bounds {
+		if bound.test == nil {
+			if bound.expr != nil {
+				t.Errorf("test[%d] - %q - %s ==> expected: <nil> actual: %q", idx, input, bound.name, bound.expr.String())
+				return false
+			}
+			continue
+		}
+
+		if !testExpression(t, idx, input, bound.expr, bound.test) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func testMacroExpression(t *testing.T, idx int, input string, expr ast.Expression, params []string, body *BlockStatementTest) bool {
 	if "macro" != expr.TokenLexeme() {
 		t.Errorf("test[%d] - %q - exp.TokenLexeme() ==> expected: 'macro' actual: %q", idx, input, expr.TokenLexeme())
@@ -26,9 +26,10 @@ const MONKEY_FACE = `
           '-----'
 `
 
-func Start(in io.Reader, out io.Writer) {
+func Start(in io.Reader, out io.Writer, strict bool) {
 	scanner := bufio.NewScanner(in)
 	env := object.NewEnvironment()
+	env.SetStrict(strict)
 	macros := object.NewEnvironment()
 
 	io.WriteString(out, MONKEY_FACE)
@@ -50,7 +51,15 @@ func Start(in io.Reader, out io.Writer) {
 				switch line {
 				case ".help":
 					io.WriteString(out, ".help    Print this help message\n")
+					io.WriteString(out, ".strict  Toggle strict indexing\n")
 					io.WriteString(out, ".exit    Exit the REPL\n")
+				case ".strict":
+					env.SetStrict(!env.Strict())
+					if env.Strict() {
+						io.WriteString(out, "strict indexing on\n")
+					} else {
+						io.WriteString(out, "strict indexing off\n")
+					}
 				case ".exit":
 					os.Exit(0)
 				}
@@ -61,8 +70,9 @@ func Start(in io.Reader, out io.Writer) {
 	}
 }
 
-func Script(in string, out io.Writer) {
+func Script(in string, out io.Writer, strict bool) {
 	env := object.NewEnvironment()
+	env.SetStrict(strict)
 	macros := object.NewEnvironment()
 
 	bytes, err := os.ReadFile(in)
@@ -91,8 +101,87 @@ func run(input string, out io.Writer, env, macros *object.Environment) {
 	expanded := evaluator.ExpandMacro(program, macros)
 
 	evaluated := evaluator.Eval(expanded, env)
-	if evaluated != nil {
+	if err, ok := evaluated.(*object.Error); ok {
+		printError(out, err)
+	} else if evaluated != nil {
 		io.WriteString(out, evaluated.Inspect())
 		io.WriteString(out, "\n")
 	}
 }
+
+// MAX_TRACE_CYCLE is the longest run of trace lines collapsed as a repeat.
+const MAX_TRACE_CYCLE = 8
+
+// MAX_TRACE_LINES is the number of trace lines kept from each end of a long trace.
+const MAX_TRACE_LINES = 20
+
+// printError prints a runtime error followed by its Monkey stack trace, innermost first.
+func printError(out io.Writer, err *object.Error) {
+	if err.Line == 0 {
+		fmt.Fprintf(out, "ERROR: %s\n", err.Message)
+	} else {
+		fmt.Fprintf(out, "ERROR: %d:%d: %s\n", err.Line, err.Column, err.Message)
+	}
+
+	if len(err.Stack) == 0 {
+		return
+	}
+
+	lines := []string{}
+	line, column := err.Line, err.Column
+	for _, frame := range err.Stack {
+		lines = append(lines, fmt.Sprintf("at %s (%d:%d)", frame.Function, line, column))
+		line, column = frame.Line, frame.Column
+	}
+	lines = append(lines, fmt.Sprintf("at %d:%d", line, column))
+
+	trace := []string{}
+	for i := 0; i < len(lines); {
+		size, count := cycle(lines[i:])
+		trace = append(trace, lines[i:i+size]...)
+		if count > 1 && size == 1 {
+			trace = append(trace, fmt.Sprintf("... repeated %d more times", count-1))
+		} else if count > 1 {
+			trace = append(trace, fmt.Sprintf("... previous %d lines repeated %d more times", size, count-1))
+		}
+
+		i = i + size*count
+	}
+
+	if len(trace) > 2*MAX_TRACE_LINES {
+		omitted := len(trace) - 2*MAX_TRACE_LINES
+		trace = append(
+			append(trace[:MAX_TRACE_LINES:MAX_TRACE_LINES], fmt.Sprintf("... %d lines omitted", omitted)),
+			trace[len(trace)-MAX_TRACE_LINES:]...,
+		)
+	}
+
+	for _, line := range trace {
+		fmt.Fprintf(out, "    %s\n", line)
+	}
+}
+
+// cycle returns the size of the shortest run starting lines and how many times it repeats.
+func cycle(lines []string) (int, int) {
+	for size := 1; size <= MAX_TRACE_CYCLE && 2*size <= len(lines); size++ {
+		count := 1
+		for (count+1)*size <= len(lines) && equalLines(lines[:size], lines[count*size:(count+1)*size]) {
+			count = count + 1
+		}
+
+		if count > 1 {
+			return size, count
+		}
+	}
+
+	return 1, 1
+}
+
+func equalLines(left, right []string) bool {
+	for i := range left {
+		if left[i] != right[i] {
+			return false
+		}
+	}
+	return true
+}